@@ -0,0 +1,41 @@
+package commander
+
+import (
+	"context"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/core"
+	"google.golang.org/grpc"
+)
+
+// reloadServer is an implementation of ReloadService.
+type reloadServer struct {
+	v *core.Instance
+}
+
+// Reload implements ReloadService.
+func (s *reloadServer) Reload(ctx context.Context, request *ReloadRequest) (*ReloadResponse, error) {
+	if request.Config == nil {
+		return nil, newError("Invalid reload request.")
+	}
+	if err := s.v.Reload(request.Config); err != nil {
+		return nil, err
+	}
+	return &ReloadResponse{}, nil
+}
+
+func (s *reloadServer) mustEmbedUnimplementedReloadServiceServer() {}
+
+type reloadService struct {
+	v *core.Instance
+}
+
+func (s *reloadService) Register(server *grpc.Server) {
+	RegisterReloadServiceServer(server, &reloadServer{v: s.v})
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*ReloadConfig)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		return &reloadService{v: core.MustFromContext(ctx)}, nil
+	}))
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/xtls/xray-core/features/stats"
 	"github.com/xtls/xray-core/transport"
 	"github.com/xtls/xray-core/transport/pipe"
+	"golang.org/x/time/rate"
 )
 
 var errSniffingTimeout = newError("timeout on sniffing")
@@ -135,7 +136,8 @@ func (*DefaultDispatcher) Start() error {
 // Close implements common.Closable.
 func (*DefaultDispatcher) Close() error { return nil }
 
-func (d *DefaultDispatcher) getLink(ctx context.Context, network net.Network, sniffing session.SniffingRequest) (*transport.Link, *transport.Link) {
+// getLink creates the links of a session. The returned function must be called when the session ends.
+func (d *DefaultDispatcher) getLink(ctx context.Context, network net.Network, sniffing session.SniffingRequest) (*transport.Link, *transport.Link, func()) {
 	downOpt := pipe.OptionsFromContext(ctx)
 	upOpt := downOpt
 
@@ -166,7 +168,7 @@ func (d *DefaultDispatcher) getLink(ctx context.Context, network net.Network, sn
 						newError("[fakedns client] create a new map").WriteToLog(session.ExportIDToError(ctx))
 					}
 					domain := addr.Domain()
-					ips, err := d.dns.LookupIP(domain, dns.IPOption{true, true, false})
+					ips, err := dns.LookupIPWithContext(ctx, d.dns, domain, dns.IPOption{true, true, false})
 					if err == nil {
 						for _, ip := range ips {
 							ip2domain.Store(ip.String(), domain)
@@ -217,6 +219,17 @@ func (d *DefaultDispatcher) getLink(ctx context.Context, network net.Network, sn
 		Writer: downlinkWriter,
 	}
 
+	if s := sessionStatsFromContext(ctx); s != nil {
+		inboundLink.Writer = &SizeStatWriter{
+			Counter: &s.uplink,
+			Writer:  inboundLink.Writer,
+		}
+		outboundLink.Writer = &SizeStatWriter{
+			Counter: &s.downlink,
+			Writer:  outboundLink.Writer,
+		}
+	}
+
 	sessionInbound := session.InboundFromContext(ctx)
 	var user *protocol.MemoryUser
 	if sessionInbound != nil {
@@ -225,27 +238,130 @@ func (d *DefaultDispatcher) getLink(ctx context.Context, network net.Network, sn
 
 	if user != nil && len(user.Email) > 0 {
 		p := d.policy.ForLevel(user.Level)
-		if p.Stats.UserUplink {
-			name := "user>>>" + user.Email + ">>>traffic>>>uplink"
-			if c, _ := stats.GetOrRegisterCounter(d.stats, name); c != nil {
+		// Traffic of users with quota is always counted, as the quota is checked against it.
+		var uplink, downlink stats.Counter
+		if p.Stats.UserUplink || user.Quota > 0 {
+			uplink, _ = stats.GetOrRegisterCounter(d.stats, "user>>>"+user.Email+">>>traffic>>>uplink")
+		}
+		if p.Stats.UserDownlink || user.Quota > 0 {
+			downlink, _ = stats.GetOrRegisterCounter(d.stats, "user>>>"+user.Email+">>>traffic>>>downlink")
+		}
+		if user.Quota > 0 && uplink != nil && downlink != nil {
+			inboundLink.Writer = &QuotaWriter{
+				Counter: uplink,
+				Used:    []stats.Counter{uplink, downlink},
+				Quota:   user.Quota,
+				Writer:  inboundLink.Writer,
+			}
+			outboundLink.Writer = &QuotaWriter{
+				Counter: downlink,
+				Used:    []stats.Counter{uplink, downlink},
+				Quota:   user.Quota,
+				Writer:  outboundLink.Writer,
+			}
+		} else {
+			if uplink != nil {
 				inboundLink.Writer = &SizeStatWriter{
-					Counter: c,
+					Counter: uplink,
 					Writer:  inboundLink.Writer,
 				}
 			}
-		}
-		if p.Stats.UserDownlink {
-			name := "user>>>" + user.Email + ">>>traffic>>>downlink"
-			if c, _ := stats.GetOrRegisterCounter(d.stats, name); c != nil {
+			if downlink != nil {
 				outboundLink.Writer = &SizeStatWriter{
-					Counter: c,
+					Counter: downlink,
 					Writer:  outboundLink.Writer,
 				}
 			}
 		}
 	}
 
-	return inboundLink, outboundLink
+	release := func() {}
+	if bm, ok := d.policy.(policy.BandwidthManager); ok {
+		if user != nil {
+			var uplink, downlink *rate.Limiter
+			uplink, downlink, release = bm.LimitersForUser(user.Level, user.Email)
+			limitLink(ctx, inboundLink, outboundLink, uplink, downlink)
+		}
+		if sessionInbound != nil {
+			uplink, downlink := bm.LimitersForInbound(sessionInbound.Tag)
+			limitLink(ctx, inboundLink, outboundLink, uplink, downlink)
+		}
+	}
+
+	return inboundLink, outboundLink, release
+}
+
+func limitLink(ctx context.Context, inboundLink, outboundLink *transport.Link, uplink, downlink *rate.Limiter) {
+	if uplink != nil {
+		inboundLink.Writer = &RateLimitWriter{
+			Context: ctx,
+			Limiter: uplink,
+			Writer:  inboundLink.Writer,
+		}
+	}
+	if downlink != nil {
+		outboundLink.Writer = &RateLimitWriter{
+			Context: ctx,
+			Limiter: downlink,
+			Writer:  outboundLink.Writer,
+		}
+	}
+}
+
+// acquireSession checks the expiry, quota and connection limits of the user in ctx.
+// The returned function must be called when the session ends, which is when its link is closed.
+func (d *DefaultDispatcher) acquireSession(ctx context.Context) (func(), error) {
+	inbound := session.InboundFromContext(ctx)
+	if inbound == nil || inbound.User == nil {
+		return func() {}, nil
+	}
+	user := inbound.User
+
+	err := d.checkQuota(user)
+	release := func() {}
+	if limiter, ok := d.policy.(policy.ConnectionLimiter); ok && err == nil && len(user.Email) > 0 {
+		var source net.Address
+		if inbound.Source.IsValid() {
+			source = inbound.Source.Address
+		}
+		release, err = limiter.AcquireSession(user.Level, user.Email, source)
+	}
+	if err != nil {
+		if len(user.Email) > 0 {
+			name := "user>>>" + user.Email + ">>>connection>>>rejected"
+			if c, _ := stats.GetOrRegisterCounter(d.stats, name); c != nil {
+				c.Add(1)
+			}
+		}
+		if accessMessage := log.AccessMessageFromContext(ctx); accessMessage != nil {
+			accessMessage.Status = log.AccessRejected
+			accessMessage.Reason = err
+			log.Record(accessMessage)
+		}
+		return nil, newError("connection rejected").Base(err)
+	}
+	return release, nil
+}
+
+// checkQuota returns an error if the user has expired or used up its traffic quota.
+func (d *DefaultDispatcher) checkQuota(user *protocol.MemoryUser) error {
+	if user.Expiry > 0 && time.Now().Unix() >= user.Expiry {
+		return newError("user ", user.Email, " expired at ", time.Unix(user.Expiry, 0).Format(time.RFC3339))
+	}
+	if user.Quota > 0 && len(user.Email) > 0 {
+		var used []stats.Counter
+		for _, direction := range []string{"uplink", "downlink"} {
+			c, _ := stats.GetOrRegisterCounter(d.stats, "user>>>"+user.Email+">>>traffic>>>"+direction)
+			if c == nil {
+				return newError("traffic quota of user ", user.Email, " requires stats")
+			}
+			used = append(used, c)
+		}
+		if quotaUsed(used) >= user.Quota {
+			return newError("user ", user.Email, " used up its traffic quota of ", user.Quota, " bytes")
+		}
+	}
+	return nil
 }
 
 func (d *DefaultDispatcher) shouldOverride(ctx context.Context, result SniffResult, request session.SniffingRequest, destination net.Destination) bool {
@@ -286,6 +402,10 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 	if !destination.IsValid() {
 		panic("Dispatcher: Invalid destination.")
 	}
+	release, err := d.acquireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
 	ob := &session.Outbound{
 		Target: destination,
 	}
@@ -295,11 +415,16 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 		content = new(session.Content)
 		ctx = session.ContextWithContent(ctx, content)
 	}
+	ctx = d.contextWithSessionStats(ctx)
 
 	sniffingRequest := content.SniffingRequest
-	inbound, outbound := d.getLink(ctx, destination.Network, sniffingRequest)
+	inbound, outbound, releaseLink := d.getLink(ctx, destination.Network, sniffingRequest)
+	end := newSessionEnd(outbound.Writer)
+	end.OnEnd(release)
+	end.OnEnd(releaseLink)
+	outbound.Writer = end
 	if !sniffingRequest.Enabled {
-		go d.routedDispatch(ctx, outbound, destination)
+		go d.routedDispatch(ctx, outbound, destination, end)
 	} else {
 		go func() {
 			cReader := &cachedReader{
@@ -309,6 +434,7 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 			result, err := sniffer(ctx, cReader, sniffingRequest.MetadataOnly, destination.Network)
 			if err == nil {
 				content.Protocol = result.Protocol()
+				recordSniffResult(ctx, result)
 			}
 			if err == nil && d.shouldOverride(ctx, result, sniffingRequest, destination) {
 				domain := result.Domain()
@@ -320,7 +446,7 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 					ob.Target = destination
 				}
 			}
-			d.routedDispatch(ctx, outbound, destination)
+			d.routedDispatch(ctx, outbound, destination, end)
 		}()
 	}
 	return inbound, nil
@@ -331,6 +457,10 @@ func (d *DefaultDispatcher) DispatchLink(ctx context.Context, destination net.De
 	if !destination.IsValid() {
 		return newError("Dispatcher: Invalid destination.")
 	}
+	release, err := d.acquireSession(ctx)
+	if err != nil {
+		return err
+	}
 	ob := &session.Outbound{
 		Target: destination,
 	}
@@ -340,9 +470,20 @@ func (d *DefaultDispatcher) DispatchLink(ctx context.Context, destination net.De
 		content = new(session.Content)
 		ctx = session.ContextWithContent(ctx, content)
 	}
+	// Uplink traffic of the given link can't be counted, only downlink is recorded.
+	ctx = d.contextWithSessionStats(ctx)
+	if s := sessionStatsFromContext(ctx); s != nil {
+		outbound.Writer = &SizeStatWriter{
+			Counter: &s.downlink,
+			Writer:  outbound.Writer,
+		}
+	}
+	end := newSessionEnd(outbound.Writer)
+	end.OnEnd(release)
+	outbound.Writer = end
 	sniffingRequest := content.SniffingRequest
 	if !sniffingRequest.Enabled {
-		d.routedDispatch(ctx, outbound, destination)
+		d.routedDispatch(ctx, outbound, destination, end)
 	} else {
 		cReader := &cachedReader{
 			reader: outbound.Reader.(*pipe.Reader),
@@ -351,6 +492,7 @@ func (d *DefaultDispatcher) DispatchLink(ctx context.Context, destination net.De
 		result, err := sniffer(ctx, cReader, sniffingRequest.MetadataOnly, destination.Network)
 		if err == nil {
 			content.Protocol = result.Protocol()
+			recordSniffResult(ctx, result)
 		}
 		if err == nil && d.shouldOverride(ctx, result, sniffingRequest, destination) {
 			domain := result.Domain()
@@ -362,7 +504,7 @@ func (d *DefaultDispatcher) DispatchLink(ctx context.Context, destination net.De
 				ob.Target = destination
 			}
 		}
-		d.routedDispatch(ctx, outbound, destination)
+		d.routedDispatch(ctx, outbound, destination, end)
 	}
 
 	return nil
@@ -414,7 +556,9 @@ func sniffer(ctx context.Context, cReader *cachedReader, metadataOnly bool, netw
 	return contentResult, contentErr
 }
 
-func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.Link, destination net.Destination) {
+// routedDispatch dispatches link to the outbound picked by the router. The session ends when the
+// outbound closes or interrupts the writer of link, which is end.
+func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.Link, destination net.Destination, end *sessionEnd) {
 	ob := session.OutboundFromContext(ctx)
 	if hosts, ok := d.dns.(dns.HostsLookup); ok && destination.Address.Family().IsDomain() {
 		proxied := hosts.LookupHosts(ob.Target.String())
@@ -472,7 +616,11 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 		return
 	}
 
-	if accessMessage := log.AccessMessageFromContext(ctx); accessMessage != nil {
+	accessMessage := log.AccessMessageFromContext(ctx)
+	if accessMessage != nil {
+		accessMessage.SessionID = uint32(session.IDFromContext(ctx))
+		accessMessage.InboundTag = inTag
+		accessMessage.OutboundTag = handler.Tag()
 		if tag := handler.Tag(); tag != "" {
 			if inTag == "" {
 				accessMessage.Detour = tag
@@ -487,5 +635,12 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 		log.Record(accessMessage)
 	}
 
+	ctx, untrack := d.trackConnection(ctx, link, handler.Tag(), destination)
+	end.OnEnd(untrack)
+	if accessMessage != nil {
+		end.OnEnd(func() {
+			recordAccessClosed(ctx, accessMessage)
+		})
+	}
 	handler.Dispatch(ctx, link)
 }
@@ -0,0 +1,45 @@
+package dispatcher
+
+import (
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/features/stats"
+)
+
+// QuotaWriter is a buf.Writer that counts traffic on Counter, and fails once the traffic counted by
+// Used, such as the uplink and downlink traffic of a user, reaches the quota. The counters may be
+// shared by writers of many sessions.
+type QuotaWriter struct {
+	Counter stats.Counter
+	Used    []stats.Counter
+	Quota   uint64
+	Writer  buf.Writer
+}
+
+func (w *QuotaWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	if quotaUsed(w.Used) >= w.Quota {
+		buf.ReleaseMulti(mb)
+		return newError("traffic quota of ", w.Quota, " bytes is used up")
+	}
+	w.Counter.Add(int64(mb.Len()))
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *QuotaWriter) Close() error {
+	return common.Close(w.Writer)
+}
+
+func (w *QuotaWriter) Interrupt() {
+	common.Interrupt(w.Writer)
+}
+
+// quotaUsed returns the sum of counters, where negative values are ignored.
+func quotaUsed(counters []stats.Counter) uint64 {
+	var used uint64
+	for _, c := range counters {
+		if v := c.Value(); v > 0 {
+			used += uint64(v)
+		}
+	}
+	return used
+}
@@ -0,0 +1,36 @@
+package dispatcher_test
+
+import (
+	"testing"
+
+	. "github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/features/stats"
+)
+
+func TestQuotaWriter(t *testing.T) {
+	var c, other TestCounter
+	other.Set(1)
+	writer := &QuotaWriter{
+		Counter: &c,
+		Used:    []stats.Counter{&c, &other},
+		Quota:   6,
+		Writer:  buf.Discard,
+	}
+
+	mb := buf.MergeBytes(nil, []byte("abcd"))
+	common.Must(writer.WriteMultiBuffer(mb))
+
+	// The write crossing the quota still goes through.
+	mb = buf.MergeBytes(nil, []byte("efg"))
+	common.Must(writer.WriteMultiBuffer(mb))
+
+	mb = buf.MergeBytes(nil, []byte("h"))
+	if err := writer.WriteMultiBuffer(mb); err == nil {
+		t.Fatal("expect error after quota is used up")
+	}
+	if c.Value() != 7 {
+		t.Fatal("unexpected counter value. want 7, but got ", c.Value())
+	}
+}
@@ -0,0 +1,41 @@
+package dispatcher
+
+import (
+	"context"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitWriter is a buf.Writer that waits on a token bucket before each write.
+type RateLimitWriter struct {
+	Context context.Context
+	Limiter *rate.Limiter
+	Writer  buf.Writer
+}
+
+func (w *RateLimitWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	n := int(mb.Len())
+	for n > 0 && w.Limiter.Limit() != rate.Inf {
+		// WaitN refuses to wait for more tokens than the bucket can hold.
+		k := n
+		if burst := w.Limiter.Burst(); k > burst && burst > 0 {
+			k = burst
+		}
+		if err := w.Limiter.WaitN(w.Context, k); err != nil {
+			buf.ReleaseMulti(mb)
+			return err
+		}
+		n -= k
+	}
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *RateLimitWriter) Close() error {
+	return common.Close(w.Writer)
+}
+
+func (w *RateLimitWriter) Interrupt() {
+	common.Interrupt(w.Writer)
+}
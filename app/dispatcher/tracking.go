@@ -0,0 +1,184 @@
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/features/stats"
+	"github.com/xtls/xray-core/transport"
+)
+
+type dispatcherKey int
+
+const sessionStatsKey dispatcherKey = iota
+
+// sizeCounter is a stats.Counter that is not registered to stats manager.
+type sizeCounter struct {
+	value int64
+}
+
+func (c *sizeCounter) Value() int64 {
+	return atomic.LoadInt64(&c.value)
+}
+
+func (c *sizeCounter) Set(newValue int64) int64 {
+	return atomic.SwapInt64(&c.value, newValue)
+}
+
+func (c *sizeCounter) Add(delta int64) int64 {
+	return atomic.AddInt64(&c.value, delta) - delta
+}
+
+// sessionEnd wraps the downlink writer of a session, and runs the functions added by OnEnd once
+// the outbound closes or interrupts it. Outbounds like mux keep using the link after their Dispatch
+// returns, so the session ends with the link instead.
+type sessionEnd struct {
+	buf.Writer
+
+	access sync.Mutex
+	ended  bool
+	hooks  []func()
+}
+
+func newSessionEnd(writer buf.Writer) *sessionEnd {
+	return &sessionEnd{
+		Writer: writer,
+	}
+}
+
+// OnEnd adds f to be called when the session ends, in reverse order like deferred calls. f is
+// called at once if the session has ended.
+func (e *sessionEnd) OnEnd(f func()) {
+	e.access.Lock()
+	if !e.ended {
+		e.hooks = append(e.hooks, f)
+		e.access.Unlock()
+		return
+	}
+	e.access.Unlock()
+	f()
+}
+
+func (e *sessionEnd) end() {
+	e.access.Lock()
+	if e.ended {
+		e.access.Unlock()
+		return
+	}
+	e.ended = true
+	hooks := e.hooks
+	e.hooks = nil
+	e.access.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
+
+// Close implements common.Closable.
+func (e *sessionEnd) Close() error {
+	err := common.Close(e.Writer)
+	e.end()
+	return err
+}
+
+// Interrupt implements common.Interruptible.
+func (e *sessionEnd) Interrupt() {
+	common.Interrupt(e.Writer)
+	e.end()
+}
+
+// sessionStats records a session for the access log and the connection tracker.
+type sessionStats struct {
+	start    time.Time
+	domain   string
+	uplink   sizeCounter
+	downlink sizeCounter
+}
+
+// contextWithSessionStats starts recording the session in ctx, if it is needed by
+// the access log or the connection tracker.
+func (d *DefaultDispatcher) contextWithSessionStats(ctx context.Context) context.Context {
+	if _, ok := d.stats.(stats.ConnectionTracker); !ok && log.AccessMessageFromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionStatsKey, &sessionStats{
+		start: time.Now(),
+	})
+}
+
+func sessionStatsFromContext(ctx context.Context) *sessionStats {
+	if s, ok := ctx.Value(sessionStatsKey).(*sessionStats); ok {
+		return s
+	}
+	return nil
+}
+
+// recordSniffResult saves the sniffed protocol and domain of the session in ctx.
+func recordSniffResult(ctx context.Context, result SniffResult) {
+	if s := sessionStatsFromContext(ctx); s != nil {
+		s.domain = result.Domain()
+	}
+	if accessMessage := log.AccessMessageFromContext(ctx); accessMessage != nil {
+		accessMessage.Protocol = result.Protocol()
+		accessMessage.Domain = result.Domain()
+	}
+}
+
+// recordAccessClosed records a copy of accessMessage, with the traffic and duration of the session in ctx.
+func recordAccessClosed(ctx context.Context, accessMessage *log.AccessMessage) {
+	closed := *accessMessage
+	closed.Status = log.AccessClosed
+	if s := sessionStatsFromContext(ctx); s != nil {
+		closed.Uplink = s.uplink.Value()
+		closed.Downlink = s.downlink.Value()
+		closed.Duration = time.Since(s.start)
+	}
+	log.Record(&closed)
+}
+
+// trackConnection adds the session in ctx to the connection tracker, if any. Closing the connection
+// cancels the returned context and interrupts link. The returned function must be called when the
+// session ends, as the context is used by the outbound until then.
+func (d *DefaultDispatcher) trackConnection(ctx context.Context, link *transport.Link, outboundTag string, destination net.Destination) (context.Context, func()) {
+	tracker, ok := d.stats.(stats.ConnectionTracker)
+	s := sessionStatsFromContext(ctx)
+	if !ok || s == nil {
+		return ctx, func() {}
+	}
+
+	conn := &stats.Connection{
+		SessionID:   uint32(session.IDFromContext(ctx)),
+		Target:      destination,
+		Domain:      s.domain,
+		OutboundTag: outboundTag,
+		Uplink:      &s.uplink,
+		Downlink:    &s.downlink,
+		StartTime:   s.start,
+	}
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		conn.InboundTag = inbound.Tag
+		conn.Source = inbound.Source
+		if inbound.User != nil {
+			conn.Email = inbound.User.Email
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	untrack := tracker.TrackConnection(conn, func() {
+		cancel()
+		common.Interrupt(link.Reader)
+		common.Interrupt(link.Writer)
+	})
+	return ctx, func() {
+		untrack()
+		cancel()
+	}
+}
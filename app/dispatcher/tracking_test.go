@@ -0,0 +1,31 @@
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/transport/pipe"
+)
+
+func TestSessionEnd(t *testing.T) {
+	_, writer := pipe.New()
+	end := newSessionEnd(writer)
+
+	var calls []int
+	end.OnEnd(func() { calls = append(calls, 1) })
+	end.OnEnd(func() { calls = append(calls, 2) })
+	if len(calls) != 0 {
+		t.Fatal("session ended before the link is closed: ", calls)
+	}
+
+	common.Must(end.Close())
+	end.Interrupt()
+	if len(calls) != 2 || calls[0] != 2 || calls[1] != 1 {
+		t.Fatal("unexpected calls: ", calls)
+	}
+
+	end.OnEnd(func() { calls = append(calls, 3) })
+	if len(calls) != 3 || calls[2] != 3 {
+		t.Fatal("function added after the end is not called: ", calls)
+	}
+}
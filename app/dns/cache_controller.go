@@ -0,0 +1,321 @@
+package dns
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/common/signal/pubsub"
+	"github.com/xtls/xray-core/common/task"
+	"github.com/xtls/xray-core/core"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// defaultStaleTTL is the time that expired records are served for if not configured.
+	defaultStaleTTL = 24 * time.Hour
+	// refreshTimeout is the time before another background refresh of a domain may be sent.
+	refreshTimeout = 8 * time.Second
+	// prefetchMinHits is the number of cache hits for a record to be prefetched.
+	prefetchMinHits = 2
+)
+
+// CacheController is the DNS cache of a name server. It applies TTL limits, serves stale
+// records, prefetches popular records and evicts the least recently used domains.
+type CacheController struct {
+	sync.Mutex
+	name       string
+	config     *CacheConfig
+	ips        map[string]*record
+	lru        *list.List
+	refreshing map[string]time.Time
+	pub        *pubsub.Service
+	cleanup    *task.Periodic
+	// closed is whether the name server of the cache is closed, after which the cache is not
+	// cleaned up any more.
+	closed bool
+}
+
+// NewCacheController creates a DNS cache for the name server of the given name.
+func NewCacheController(name string, config *CacheConfig) *CacheController {
+	c := &CacheController{
+		name:       name,
+		config:     config,
+		ips:        make(map[string]*record),
+		lru:        list.New(),
+		refreshing: make(map[string]time.Time),
+		pub:        pubsub.NewService(),
+	}
+	c.cleanup = &task.Periodic{
+		Interval: time.Minute,
+		Execute:  c.Cleanup,
+	}
+	return c
+}
+
+// staleTTL returns the time that expired records are served for.
+func (c *CacheController) staleTTL() time.Duration {
+	if !c.config.GetServeStale() {
+		return 0
+	}
+	if ttl := c.config.GetStaleTtl(); ttl > 0 {
+		return time.Duration(ttl) * time.Second
+	}
+	return defaultStaleTTL
+}
+
+// Cleanup clears expired items from cache
+func (c *CacheController) Cleanup() error {
+	now := time.Now()
+	c.Lock()
+	defer c.Unlock()
+
+	if len(c.ips) == 0 {
+		return newError(c.name, " nothing to do. stopping...")
+	}
+
+	staleTTL := c.staleTTL()
+	for domain, record := range c.ips {
+		if record.A != nil && record.A.Expire.Add(staleTTL).Before(now) {
+			record.A = nil
+		}
+		if record.AAAA != nil && record.AAAA.Expire.Add(staleTTL).Before(now) {
+			record.AAAA = nil
+		}
+
+		if record.A == nil && record.AAAA == nil {
+			newError(c.name, " cleanup ", domain).AtDebug().WriteToLog()
+			c.lru.Remove(record.element)
+			delete(c.ips, domain)
+		}
+	}
+
+	if len(c.ips) == 0 {
+		c.ips = make(map[string]*record)
+	}
+
+	for domain, expire := range c.refreshing {
+		if expire.Before(now) {
+			delete(c.refreshing, domain)
+		}
+	}
+
+	return nil
+}
+
+// Close stops cleaning up the cache, and drops the records in it. It is called when the name server
+// is closed, such as replaced by reloading.
+func (c *CacheController) Close() error {
+	c.Lock()
+	c.closed = true
+	c.ips = make(map[string]*record)
+	c.lru.Init()
+	c.Unlock()
+
+	return c.cleanup.Close()
+}
+
+// startCleanup starts cleaning up the cache, unless it is closed.
+func (c *CacheController) startCleanup() {
+	common.Must(c.cleanup.Start())
+	// Queries in flight may update the cache after it is closed.
+	c.Lock()
+	closed := c.closed
+	c.Unlock()
+	if closed {
+		c.cleanup.Close()
+	}
+}
+
+// clampTTL limits the TTL of the record to the configured minimum and maximum.
+func (c *CacheController) clampTTL(r *IPRecord, now time.Time) {
+	if r == nil {
+		return
+	}
+	ttl := r.Expire.Sub(now)
+	if minTTL := time.Duration(c.config.GetMinTtl()) * time.Second; ttl < minTTL {
+		ttl = minTTL
+	}
+	if maxTTL := time.Duration(c.config.GetMaxTtl()) * time.Second; maxTTL > 0 && ttl > maxTTL {
+		ttl = maxTTL
+	}
+	r.Expire = now.Add(ttl)
+}
+
+func (c *CacheController) updateIP(domain string, newRec *record) {
+	now := time.Now()
+	c.clampTTL(newRec.A, now)
+	c.clampTTL(newRec.AAAA, now)
+
+	c.Lock()
+	rec, found := c.ips[domain]
+	if !found {
+		rec = &record{}
+	}
+
+	updated := false
+	if isNewer(rec.A, newRec.A) {
+		rec.A = newRec.A
+		updated = true
+	}
+	if isNewer(rec.AAAA, newRec.AAAA) {
+		rec.AAAA = newRec.AAAA
+		updated = true
+	}
+
+	if updated {
+		newError(c.name, " updating IP records for domain:", domain).AtDebug().WriteToLog()
+		rec.updated = now
+		rec.hits = 0
+		if found {
+			c.lru.MoveToFront(rec.element)
+		} else {
+			rec.element = c.lru.PushFront(domain)
+			c.ips[domain] = rec
+			c.evict()
+		}
+	}
+	delete(c.refreshing, domain)
+	if newRec.A != nil {
+		c.pub.Publish(domain+"4", nil)
+	}
+	if newRec.AAAA != nil {
+		c.pub.Publish(domain+"6", nil)
+	}
+	c.Unlock()
+	c.startCleanup()
+}
+
+// evict removes the least recently used domains if the cache is full.
+func (c *CacheController) evict() {
+	maxEntries := int(c.config.GetMaxEntries())
+	if maxEntries <= 0 {
+		return
+	}
+	for c.lru.Len() > maxEntries {
+		domain := c.lru.Remove(c.lru.Back()).(string)
+		newError(c.name, " evict ", domain).AtDebug().WriteToLog()
+		delete(c.ips, domain)
+	}
+}
+
+// getIPs returns the IPs of the record, and whether the record should be refreshed in background.
+func (c *CacheController) getIPs(r *IPRecord, rec *record, now time.Time) ([]net.Address, bool, error) {
+	if r == nil {
+		return nil, false, errRecordNotFound
+	}
+	refresh := false
+	if r.Expire.Before(now) {
+		if !r.Expire.Add(c.staleTTL()).After(now) {
+			return nil, false, errRecordNotFound
+		}
+		refresh = true
+	} else if c.config.GetPrefetch() && rec.hits >= prefetchMinHits {
+		// Popular records are refreshed within the last tenth of their TTL.
+		refresh = r.Expire.Sub(now) < r.Expire.Sub(rec.updated)/10
+	}
+	if r.RCode != dnsmessage.RCodeSuccess {
+		return nil, refresh, dns_feature.RCodeError(r.RCode)
+	}
+	return r.IP, refresh, nil
+}
+
+// findIPsForDomain returns the unexpired IPs of the domain.
+func (c *CacheController) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	record, found := c.ips[domain]
+	if !found {
+		return nil, errRecordNotFound
+	}
+
+	var err4 error
+	var err6 error
+	var ips []net.Address
+	var ip6 []net.Address
+
+	if option.IPv4Enable {
+		ips, err4 = record.A.getIPs()
+	}
+
+	if option.IPv6Enable {
+		ip6, err6 = record.AAAA.getIPs()
+		ips = append(ips, ip6...)
+	}
+
+	return mergeIPs(ips, err4, err6)
+}
+
+// lookupIPsForDomain returns the cached IPs of the domain, including stale ones if enabled.
+// It also returns whether the domain should be queried in background to refresh the cache.
+func (c *CacheController) lookupIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, bool, error) {
+	now := time.Now()
+	c.Lock()
+	defer c.Unlock()
+
+	record, found := c.ips[domain]
+	if !found {
+		return nil, false, errRecordNotFound
+	}
+	record.hits++
+	c.lru.MoveToFront(record.element)
+
+	var err4 error
+	var err6 error
+	var refresh4, refresh6 bool
+	var ips []net.Address
+	var ip6 []net.Address
+
+	if option.IPv4Enable {
+		ips, refresh4, err4 = c.getIPs(record.A, record, now)
+	}
+
+	if option.IPv6Enable {
+		ip6, refresh6, err6 = c.getIPs(record.AAAA, record, now)
+		ips = append(ips, ip6...)
+	}
+
+	netIPs, err := mergeIPs(ips, err4, err6)
+	if err == errRecordNotFound || !(refresh4 || refresh6) {
+		return netIPs, false, err
+	}
+	if expire, found := c.refreshing[domain]; found && expire.After(now) {
+		return netIPs, false, err
+	}
+	c.refreshing[domain] = now.Add(refreshTimeout)
+	return netIPs, true, err
+}
+
+func mergeIPs(ips []net.Address, err4 error, err6 error) ([]net.IP, error) {
+	if len(ips) > 0 {
+		return toNetIP(ips)
+	}
+
+	if err4 != nil {
+		return nil, err4
+	}
+
+	if err6 != nil {
+		return nil, err6
+	}
+
+	return nil, dns_feature.ErrEmptyResponse
+}
+
+// toRefreshContext creates a context for background refresh, which is not canceled with the query.
+func toRefreshContext(ctx context.Context) context.Context {
+	if core.FromContext(ctx) == nil {
+		return context.Background()
+	}
+	refreshCtx := core.ToBackgroundDetachedContext(ctx)
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		refreshCtx = session.ContextWithInbound(refreshCtx, inbound)
+	}
+	return refreshCtx
+}
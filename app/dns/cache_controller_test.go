@@ -0,0 +1,106 @@
+package dns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+)
+
+func newTestRecord(ip string, ttl time.Duration) *record {
+	return &record{
+		A: &IPRecord{
+			IP:     []net.Address{net.ParseAddress(ip)},
+			Expire: time.Now().Add(ttl),
+		},
+	}
+}
+
+var ipv4Option = dns_feature.IPOption{IPv4Enable: true}
+
+func TestCacheControllerTTL(t *testing.T) {
+	c := NewCacheController("test", &CacheConfig{MinTtl: 60, MaxTtl: 3600})
+	defer c.cleanup.Close()
+
+	c.updateIP("short.", newTestRecord("1.1.1.1", time.Second))
+	c.updateIP("long.", newTestRecord("2.2.2.2", 24*time.Hour))
+
+	if ttl := time.Until(c.ips["short."].A.Expire); ttl < 59*time.Second || ttl > 60*time.Second {
+		t.Error("unexpected TTL of short record: ", ttl)
+	}
+	if ttl := time.Until(c.ips["long."].A.Expire); ttl < 3599*time.Second || ttl > 3600*time.Second {
+		t.Error("unexpected TTL of long record: ", ttl)
+	}
+}
+
+func TestCacheControllerServeStale(t *testing.T) {
+	c := NewCacheController("test", &CacheConfig{ServeStale: true, StaleTtl: 60})
+	defer c.cleanup.Close()
+
+	c.updateIP("example.com.", newTestRecord("1.1.1.1", 0))
+	c.ips["example.com."].A.Expire = time.Now().Add(-time.Second)
+
+	if _, err := c.findIPsForDomain("example.com.", ipv4Option); err != errRecordNotFound {
+		t.Error("expect stale record not found, but got ", err)
+	}
+
+	ips, refresh, err := c.lookupIPsForDomain("example.com.", ipv4Option)
+	common.Must(err)
+	if r := cmp.Diff(ips, []net.IP{{1, 1, 1, 1}}); r != "" {
+		t.Error(r)
+	}
+	if !refresh {
+		t.Error("expect stale record to be refreshed")
+	}
+
+	// Only one refresh is sent at a time.
+	if _, refresh, _ := c.lookupIPsForDomain("example.com.", ipv4Option); refresh {
+		t.Error("expect refresh to be sent only once")
+	}
+
+	c.ips["example.com."].A.Expire = time.Now().Add(-time.Minute * 2)
+	if _, _, err := c.lookupIPsForDomain("example.com.", ipv4Option); err != errRecordNotFound {
+		t.Error("expect record to be expired, but got ", err)
+	}
+}
+
+func TestCacheControllerPrefetch(t *testing.T) {
+	c := NewCacheController("test", &CacheConfig{Prefetch: true})
+	defer c.cleanup.Close()
+
+	c.updateIP("example.com.", newTestRecord("1.1.1.1", time.Minute))
+	rec := c.ips["example.com."]
+	// The record has 5 seconds left of its 60 seconds TTL.
+	rec.updated = time.Now().Add(-55 * time.Second)
+	rec.A.Expire = time.Now().Add(5 * time.Second)
+
+	if _, refresh, _ := c.lookupIPsForDomain("example.com.", ipv4Option); refresh {
+		t.Error("expect unpopular record not to be prefetched")
+	}
+	if _, refresh, _ := c.lookupIPsForDomain("example.com.", ipv4Option); !refresh {
+		t.Error("expect popular record to be prefetched")
+	}
+}
+
+func TestCacheControllerLRU(t *testing.T) {
+	c := NewCacheController("test", &CacheConfig{MaxEntries: 2})
+	defer c.cleanup.Close()
+
+	c.updateIP("a.", newTestRecord("1.1.1.1", time.Minute))
+	c.updateIP("b.", newTestRecord("2.2.2.2", time.Minute))
+	_, _, err := c.lookupIPsForDomain("a.", ipv4Option)
+	common.Must(err)
+	c.updateIP("c.", newTestRecord("3.3.3.3", time.Minute))
+
+	if _, err := c.findIPsForDomain("b.", ipv4Option); err != errRecordNotFound {
+		t.Error("expect least recently used domain to be evicted")
+	}
+	for _, domain := range []string{"a.", "c."} {
+		if _, err := c.findIPsForDomain(domain, ipv4Option); err != nil {
+			t.Error("expect ", domain, " to be cached, but got ", err)
+		}
+	}
+}
@@ -17,15 +17,19 @@ import (
 	"github.com/xtls/xray-core/common/strmatcher"
 	"github.com/xtls/xray-core/features"
 	"github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/features/routing"
+	routing_session "github.com/xtls/xray-core/features/routing/session"
 )
 
 // DNS is a DNS rely server.
 type DNS struct {
-	sync.Mutex
+	sync.RWMutex
 	tag                    string
 	disableCache           bool
 	disableFallback        bool
 	disableFallbackIfMatch bool
+	parallelQuery          bool
+	parallelQueryCount     int
 	ipOption               *dns.IPOption
 	hosts                  *StaticHosts
 	clients                []*Client
@@ -83,8 +87,18 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 	if err != nil {
 		return nil, newError("failed to create hosts").Base(err)
 	}
+	if err := hosts.loadFiles(config.HostsFile, config.HostsFileCheckInterval); err != nil {
+		return nil, newError("failed to load hosts files").Base(err)
+	}
 
 	clients := []*Client{}
+	// closeAll closes what is created so far if any client fails to be created.
+	closeAll := func() {
+		hosts.Close()
+		for _, client := range clients {
+			client.Close()
+		}
+	}
 	domainRuleCount := 0
 	for _, ns := range config.NameServer {
 		domainRuleCount += len(ns.PrioritizedDomain)
@@ -97,8 +111,9 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 
 	for _, endpoint := range config.NameServers {
 		features.PrintDeprecatedFeatureWarning("simple DNS server")
-		client, err := NewSimpleClient(ctx, endpoint, clientIP)
+		client, err := NewSimpleClient(ctx, endpoint, clientIP, config.Cache)
 		if err != nil {
+			closeAll()
 			return nil, newError("failed to create client").Base(err)
 		}
 		clients = append(clients, client)
@@ -120,8 +135,9 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 		case net.IPv4len, net.IPv6len:
 			myClientIP = net.IP(ns.ClientIp)
 		}
-		client, err := NewClient(ctx, ns, myClientIP, geoipContainer, &matcherInfos, updateDomain)
+		client, err := NewClient(ctx, ns, myClientIP, geoipContainer, &matcherInfos, updateDomain, config.Cache)
 		if err != nil {
+			closeAll()
 			return nil, newError("failed to create client").Base(err)
 		}
 		clients = append(clients, client)
@@ -143,6 +159,8 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 		disableCache:           config.DisableCache,
 		disableFallback:        config.DisableFallback,
 		disableFallbackIfMatch: config.DisableFallbackIfMatch,
+		parallelQuery:          config.ParallelQuery,
+		parallelQueryCount:     int(config.ParallelQueryCount),
 	}, nil
 }
 
@@ -153,28 +171,97 @@ func (*DNS) Type() interface{} {
 
 // Start implements common.Runnable.
 func (s *DNS) Start() error {
-	return nil
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.hosts.Start()
 }
 
 // Close implements common.Closable.
 func (s *DNS) Close() error {
+	s.RLock()
+	defer s.RUnlock()
+
+	errs := []error{s.hosts.Close()}
+	for _, client := range s.clients {
+		errs = append(errs, client.Close())
+	}
+	return errors.Combine(errs...)
+}
+
+// Reload implements features.Reloadable.
+func (s *DNS) Reload(config interface{}) error {
+	c, ok := config.(*Config)
+	if !ok {
+		return newError("not a DNS config")
+	}
+	n, err := New(s.ctx, c)
+	if err != nil {
+		return err
+	}
+	if err := n.hosts.Start(); err != nil {
+		n.Close()
+		return err
+	}
+
+	s.Lock()
+	oldHosts, oldClients := s.hosts, s.clients
+	s.tag = n.tag
+	s.disableCache = n.disableCache
+	s.disableFallback = n.disableFallback
+	s.disableFallbackIfMatch = n.disableFallbackIfMatch
+	s.parallelQuery = n.parallelQuery
+	s.parallelQueryCount = n.parallelQueryCount
+	s.ipOption = n.ipOption
+	s.hosts = n.hosts
+	s.clients = n.clients
+	s.domainMatcher = n.domainMatcher
+	s.matcherInfos = n.matcherInfos
+	s.Unlock()
+
+	// Queries in flight may still be using the old name servers, which fail when closed.
+	if err := oldHosts.Close(); err != nil {
+		newError("failed to close hosts").Base(err).AtWarning().WriteToLog()
+	}
+	for _, client := range oldClients {
+		if err := client.Close(); err != nil {
+			newError("failed to close name server ", client.Name()).Base(err).AtWarning().WriteToLog()
+		}
+	}
 	return nil
 }
 
 // IsOwnLink implements proxy.dns.ownLinkVerifier
 func (s *DNS) IsOwnLink(ctx context.Context) bool {
+	s.RLock()
+	defer s.RUnlock()
+
 	inbound := session.InboundFromContext(ctx)
 	return inbound != nil && inbound.Tag == s.tag
 }
 
 // LookupIP implements dns.Client.
 func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
+	return s.lookupIP(nil, domain, option)
+}
+
+// LookupIPWithContext implements dns.ContextLookup. Name servers restricted to inbounds or users are
+// chosen by the inbound of the session in ctx.
+func (s *DNS) LookupIPWithContext(ctx context.Context, domain string, option dns.IPOption) ([]net.IP, error) {
+	return s.lookupIP(routing_session.AsRoutingContext(ctx), domain, option)
+}
+
+func (s *DNS) lookupIP(routingCtx routing.Context, domain string, option dns.IPOption) ([]net.IP, error) {
 	if domain == "" {
 		return nil, newError("empty domain name")
 	}
 
-	option.IPv4Enable = option.IPv4Enable && s.ipOption.IPv4Enable
-	option.IPv6Enable = option.IPv6Enable && s.ipOption.IPv6Enable
+	s.RLock()
+	ipOption, hosts := s.ipOption, s.hosts
+	s.RUnlock()
+
+	option.IPv4Enable = option.IPv4Enable && ipOption.IPv4Enable
+	option.IPv6Enable = option.IPv6Enable && ipOption.IPv6Enable
 
 	if !option.IPv4Enable && !option.IPv6Enable {
 		return nil, dns.ErrEmptyResponse
@@ -186,7 +273,7 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
 	}
 
 	// Static host lookup
-	switch addrs := s.hosts.Lookup(domain, option); {
+	switch addrs := hosts.Lookup(domain, option); {
 	case addrs == nil: // Domain not recorded in static host
 		break
 	case len(addrs) == 0: // Domain recorded, but no valid IP returned (e.g. IPv4 address with only IPv6 enabled)
@@ -201,13 +288,44 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
 
 	// Name servers lookup
 	errs := []error{}
-	ctx := session.ContextWithInbound(s.ctx, &session.Inbound{Tag: s.tag})
-	for _, client := range s.sortClients(domain) {
+	s.RLock()
+	tag, disableCache, clients := s.tag, s.disableCache, s.sortClients(domain, routingCtx)
+	parallelQuery, parallelQueryCount := s.parallelQuery, s.parallelQueryCount
+	s.RUnlock()
+	ctx := session.ContextWithInbound(s.ctx, &session.Inbound{Tag: tag})
+
+	queryClients := make([]*Client, 0, len(clients))
+	for _, client := range clients {
 		if !option.FakeEnable && strings.EqualFold(client.Name(), "FakeDNS") {
 			newError("skip DNS resolution for domain ", domain, " at server ", client.Name()).AtDebug().WriteToLog()
 			continue
 		}
-		ips, err := client.QueryIP(ctx, domain, option, s.disableCache)
+		queryClients = append(queryClients, client)
+	}
+
+	if parallelQuery && len(queryClients) > 1 {
+		count := len(queryClients)
+		if parallelQueryCount > 0 && parallelQueryCount < count {
+			count = parallelQueryCount
+		}
+		ips, parallelErrs := s.queryIPParallel(ctx, domain, option, disableCache, queryClients[:count])
+		if len(ips) > 0 {
+			return ips, nil
+		}
+		for i, err := range parallelErrs {
+			if err != nil {
+				newError("failed to lookup ip for domain ", domain, " at server ", queryClients[i].Name()).Base(err).WriteToLog()
+				errs = append(errs, err)
+			}
+			if !canFallback(err) {
+				return nil, err
+			}
+		}
+		queryClients = queryClients[count:]
+	}
+
+	for _, client := range queryClients {
+		ips, err := client.QueryIP(ctx, domain, option, disableCache)
 		if len(ips) > 0 {
 			return ips, nil
 		}
@@ -215,7 +333,7 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
 			newError("failed to lookup ip for domain ", domain, " at server ", client.Name()).Base(err).WriteToLog()
 			errs = append(errs, err)
 		}
-		if err != context.Canceled && err != context.DeadlineExceeded && err != errExpectedIPNonMatch {
+		if !canFallback(err) {
 			return nil, err
 		}
 	}
@@ -223,14 +341,54 @@ func (s *DNS) LookupIP(domain string, option dns.IPOption) ([]net.IP, error) {
 	return nil, newError("returning nil for domain ", domain).Base(errors.Combine(errs...))
 }
 
+// canFallback returns whether the next name server should be queried after the error.
+func canFallback(err error) bool {
+	return err == context.Canceled || err == context.DeadlineExceeded || err == errExpectedIPNonMatch
+}
+
+// queryIPParallel queries the clients simultaneously and returns the first non-empty answer.
+// If none of them answers, the errors of the clients are returned in their order.
+func (s *DNS) queryIPParallel(ctx context.Context, domain string, option dns.IPOption, disableCache bool, clients []*Client) ([]net.IP, []error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		index int
+		ips   []net.IP
+		err   error
+	}
+	results := make(chan result, len(clients))
+	for i, client := range clients {
+		go func(i int, client *Client) {
+			ips, err := client.QueryIP(ctx, domain, option, disableCache)
+			results <- result{index: i, ips: ips, err: err}
+		}(i, client)
+	}
+
+	errs := make([]error, len(clients))
+	for range clients {
+		r := <-results
+		if len(r.ips) > 0 {
+			newError("domain ", domain, " answered first by server ", clients[r.index].Name()).AtDebug().WriteToLog()
+			return r.ips, nil
+		}
+		errs[r.index] = r.err
+	}
+	return nil, errs
+}
+
 // LookupHosts implements dns.HostsLookup.
 func (s *DNS) LookupHosts(domain string) *net.Address {
 	domain = strings.TrimSuffix(domain, ".")
 	if domain == "" {
 		return nil
 	}
+	s.RLock()
+	hosts, ipOption := s.hosts, s.ipOption
+	s.RUnlock()
+
 	// Normalize the FQDN form query
-	addrs := s.hosts.Lookup(domain, *s.ipOption)
+	addrs := hosts.Lookup(domain, *ipOption)
 	if len(addrs) > 0 {
 		newError("domain replaced: ", domain, " -> ", addrs[0].String()).AtInfo().WriteToLog()
 		return &addrs[0]
@@ -241,21 +399,35 @@ func (s *DNS) LookupHosts(domain string) *net.Address {
 
 // GetIPOption implements ClientWithIPOption.
 func (s *DNS) GetIPOption() *dns.IPOption {
+	s.RLock()
+	defer s.RUnlock()
+
 	return s.ipOption
 }
 
 // SetQueryOption implements ClientWithIPOption.
 func (s *DNS) SetQueryOption(isIPv4Enable, isIPv6Enable bool) {
-	s.ipOption.IPv4Enable = isIPv4Enable
-	s.ipOption.IPv6Enable = isIPv6Enable
+	s.Lock()
+	defer s.Unlock()
+
+	// The option is copied, as lookups may be reading the current one without lock.
+	ipOption := *s.ipOption
+	ipOption.IPv4Enable = isIPv4Enable
+	ipOption.IPv6Enable = isIPv6Enable
+	s.ipOption = &ipOption
 }
 
 // SetFakeDNSOption implements ClientWithIPOption.
 func (s *DNS) SetFakeDNSOption(isFakeEnable bool) {
-	s.ipOption.FakeEnable = isFakeEnable
+	s.Lock()
+	defer s.Unlock()
+
+	ipOption := *s.ipOption
+	ipOption.FakeEnable = isFakeEnable
+	s.ipOption = &ipOption
 }
 
-func (s *DNS) sortClients(domain string) []*Client {
+func (s *DNS) sortClients(domain string, routingCtx routing.Context) []*Client {
 	clients := make([]*Client, 0, len(s.clients))
 	clientUsed := make([]bool, len(s.clients))
 	clientNames := make([]string, 0, len(s.clients))
@@ -268,7 +440,7 @@ func (s *DNS) sortClients(domain string) []*Client {
 		client := s.clients[info.clientIdx]
 		domainRule := client.domains[info.domainRuleIdx]
 		domainRules = append(domainRules, fmt.Sprintf("%s(DNS idx:%d)", domainRule, info.clientIdx))
-		if clientUsed[info.clientIdx] {
+		if clientUsed[info.clientIdx] || !client.Allows(routingCtx) {
 			continue
 		}
 		clientUsed[info.clientIdx] = true
@@ -280,7 +452,7 @@ func (s *DNS) sortClients(domain string) []*Client {
 	if !(s.disableFallback || s.disableFallbackIfMatch && hasMatch) {
 		// Default round-robin query
 		for idx, client := range s.clients {
-			if clientUsed[idx] || client.skipFallback {
+			if clientUsed[idx] || client.skipFallback || !client.Allows(routingCtx) {
 				continue
 			}
 			clientUsed[idx] = true
@@ -297,9 +469,14 @@ func (s *DNS) sortClients(domain string) []*Client {
 	}
 
 	if len(clients) == 0 {
-		clients = append(clients, s.clients[0])
-		clientNames = append(clientNames, s.clients[0].Name())
-		newError("domain ", domain, " will use the first DNS: ", clientNames).AtDebug().WriteToLog()
+		for _, client := range s.clients {
+			if client.Allows(routingCtx) {
+				clients = append(clients, client)
+				clientNames = append(clientNames, client.Name())
+				newError("domain ", domain, " will use the first DNS: ", clientNames).AtDebug().WriteToLog()
+				break
+			}
+		}
 	}
 
 	return clients
@@ -1,6 +1,7 @@
 package dns
 
 import (
+	"container/list"
 	"context"
 	"encoding/binary"
 	"strings"
@@ -27,6 +28,13 @@ func Fqdn(domain string) string {
 type record struct {
 	A    *IPRecord
 	AAAA *IPRecord
+
+	// updated is the time the record was last updated.
+	updated time.Time
+	// hits is the number of cache hits since the record was updated.
+	hits uint32
+	// element is the element of the domain in LRU list of the cache.
+	element *list.Element
 }
 
 // IPRecord is a cacheable item for a resolved domain
@@ -11,6 +11,7 @@ import (
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/cache"
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/task"
 	"github.com/xtls/xray-core/features/dns"
 )
 
@@ -18,6 +19,7 @@ type Holder struct {
 	domainToIP cache.Lru
 	ipRange    *gonet.IPNet
 	mu         *sync.Mutex
+	persist    *task.Periodic
 
 	config *FakeDnsPool
 }
@@ -43,12 +45,18 @@ func (*Holder) Type() interface{} {
 
 func (fkdns *Holder) Start() error {
 	if fkdns.config != nil && fkdns.config.IpPool != "" && fkdns.config.LruSize != 0 {
-		return fkdns.initializeFromConfig()
+		if err := fkdns.initializeFromConfig(); err != nil {
+			return err
+		}
+		return fkdns.startPersistence()
 	}
 	return newError("invalid fakeDNS setting")
 }
 
 func (fkdns *Holder) Close() error {
+	if err := fkdns.stopPersistence(true); err != nil {
+		newError("failed to save fake DNS mappings").Base(err).AtWarning().WriteToLog()
+	}
 	fkdns.domainToIP = nil
 	fkdns.ipRange = nil
 	fkdns.mu = nil
@@ -70,7 +78,7 @@ func NewFakeDNSHolder() (*Holder, error) {
 }
 
 func NewFakeDNSHolderConfigOnly(conf *FakeDnsPool) (*Holder, error) {
-	return &Holder{nil, nil, nil, conf}, nil
+	return &Holder{config: conf}, nil
 }
 
 func (fkdns *Holder) initializeFromConfig() error {
@@ -142,6 +150,7 @@ func (fkdns *Holder) GetDomainFromFakeDNS(ip net.Address) string {
 }
 
 type HolderMulti struct {
+	access  sync.RWMutex
 	holders []*Holder
 
 	config *FakeDnsPoolMulti
@@ -151,7 +160,7 @@ func (h *HolderMulti) IsIPInIPPool(ip net.Address) bool {
 	if ip.Family().IsDomain() {
 		return false
 	}
-	for _, v := range h.holders {
+	for _, v := range h.getHolders() {
 		if v.IsIPInIPPool(ip) {
 			return true
 		}
@@ -161,7 +170,7 @@ func (h *HolderMulti) IsIPInIPPool(ip net.Address) bool {
 
 func (h *HolderMulti) GetFakeIPForDomain3(domain string, ipv4, ipv6 bool) []net.Address {
 	var ret []net.Address
-	for _, v := range h.holders {
+	for _, v := range h.getHolders() {
 		ret = append(ret, v.GetFakeIPForDomain3(domain, ipv4, ipv6)...)
 	}
 	return ret
@@ -169,14 +178,14 @@ func (h *HolderMulti) GetFakeIPForDomain3(domain string, ipv4, ipv6 bool) []net.
 
 func (h *HolderMulti) GetFakeIPForDomain(domain string) []net.Address {
 	var ret []net.Address
-	for _, v := range h.holders {
+	for _, v := range h.getHolders() {
 		ret = append(ret, v.GetFakeIPForDomain(domain)...)
 	}
 	return ret
 }
 
 func (h *HolderMulti) GetDomainFromFakeDNS(ip net.Address) string {
-	for _, v := range h.holders {
+	for _, v := range h.getHolders() {
 		if domain := v.GetDomainFromFakeDNS(ip); domain != "" {
 			return domain
 		}
@@ -189,7 +198,7 @@ func (h *HolderMulti) Type() interface{} {
 }
 
 func (h *HolderMulti) Start() error {
-	for _, v := range h.holders {
+	for _, v := range h.getHolders() {
 		if v.config != nil && v.config.IpPool != "" && v.config.LruSize != 0 {
 			if err := v.Start(); err != nil {
 				return newError("Cannot start all fake dns pools").Base(err)
@@ -202,7 +211,7 @@ func (h *HolderMulti) Start() error {
 }
 
 func (h *HolderMulti) Close() error {
-	for _, v := range h.holders {
+	for _, v := range h.getHolders() {
 		if err := v.Close(); err != nil {
 			return newError("Cannot close all fake dns pools").Base(err)
 		}
@@ -210,6 +219,12 @@ func (h *HolderMulti) Close() error {
 	return nil
 }
 
+func (h *HolderMulti) getHolders() []*Holder {
+	h.access.RLock()
+	defer h.access.RUnlock()
+	return h.holders
+}
+
 func (h *HolderMulti) createHolderGroups() error {
 	for _, v := range h.config.Pools {
 		holder, err := NewFakeDNSHolderConfigOnly(v)
@@ -221,8 +236,53 @@ func (h *HolderMulti) createHolderGroups() error {
 	return nil
 }
 
+// Reload implements features.Reloadable. Existing mappings are kept if their IPs are still in one
+// of the new pools.
+func (h *HolderMulti) Reload(config interface{}) error {
+	c, ok := config.(*FakeDnsPoolMulti)
+	if !ok {
+		return newError("not a fake DNS config")
+	}
+	n, err := NewFakeDNSHolderMulti(c)
+	if err != nil {
+		return err
+	}
+
+	h.access.Lock()
+	defer h.access.Unlock()
+
+	// The new pools save to the same files from now on, so the old ones stop saving before the
+	// new ones start. Their latest mappings are saved, so that the new pools don't load stale ones.
+	for _, old := range h.holders {
+		if err := old.stopPersistence(true); err != nil {
+			return err
+		}
+	}
+	if err := n.Start(); err != nil {
+		n.Close()
+		for _, old := range h.holders {
+			if err := old.startPersistence(); err != nil {
+				newError("failed to resume saving fake DNS mappings").Base(err).AtWarning().WriteToLog()
+			}
+		}
+		return err
+	}
+
+	for _, old := range h.holders {
+		old.domainToIP.Range(func(key, value interface{}) bool {
+			for _, holder := range n.holders {
+				holder.restore(key.(string), value.(net.Address))
+			}
+			return true
+		})
+	}
+	h.holders = n.holders
+	h.config = c
+	return nil
+}
+
 func NewFakeDNSHolderMulti(conf *FakeDnsPoolMulti) (*HolderMulti, error) {
-	holderMulti := &HolderMulti{nil, conf}
+	holderMulti := &HolderMulti{config: conf}
 	if err := holderMulti.createHolderGroups(); err != nil {
 		return nil, err
 	}
@@ -2,6 +2,8 @@ package fakedns
 
 import (
 	gonet "net"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -204,3 +206,85 @@ func TestFakeDNSMulti(t *testing.T) {
 		})
 	})
 }
+
+func TestFakeDNSPersist(t *testing.T) {
+	config := &FakeDnsPoolMulti{
+		Pools: []*FakeDnsPool{{
+			IpPool:      "240.0.0.0/12",
+			LruSize:     256,
+			PersistPath: filepath.Join(t.TempDir(), "fakedns.txt"),
+		}, {
+			IpPool:      "fddd:c5b4:ff5f:f4f0::/64",
+			LruSize:     256,
+			PersistPath: filepath.Join(t.TempDir(), "fakedns6.txt"),
+		}},
+	}
+
+	fakeMulti, err := NewFakeDNSHolderMulti(config)
+	common.Must(err)
+	common.Must(fakeMulti.Start())
+	address := fakeMulti.GetFakeIPForDomain("fakednstest.example.com")
+	assert.Len(t, address, 2)
+	common.Must(fakeMulti.Close())
+
+	restarted, err := NewFakeDNSHolderMulti(config)
+	common.Must(err)
+	common.Must(restarted.Start())
+	defer restarted.Close()
+
+	for _, addr := range address {
+		assert.Equal(t, "fakednstest.example.com", restarted.GetDomainFromFakeDNS(addr))
+	}
+	assert.Equal(t, address, restarted.GetFakeIPForDomain("fakednstest.example.com"))
+}
+
+func TestFakeDNSReload(t *testing.T) {
+	fakeMulti, err := NewFakeDNSHolderMulti(&FakeDnsPoolMulti{
+		Pools: []*FakeDnsPool{{
+			IpPool:  "240.0.0.0/12",
+			LruSize: 256,
+		}},
+	})
+	common.Must(err)
+	common.Must(fakeMulti.Start())
+	defer fakeMulti.Close()
+
+	address := fakeMulti.GetFakeIPForDomain("fakednstest.example.com")
+
+	common.Must(fakeMulti.Reload(&FakeDnsPoolMulti{
+		Pools: []*FakeDnsPool{{
+			IpPool:  "240.0.0.0/12",
+			LruSize: 256,
+		}, {
+			IpPool:  "fddd:c5b4:ff5f:f4f0::/64",
+			LruSize: 256,
+		}},
+	}))
+
+	assert.Equal(t, "fakednstest.example.com", fakeMulti.GetDomainFromFakeDNS(address[0]))
+	newAddress := fakeMulti.GetFakeIPForDomain("fakednstest.example.com")
+	assert.Len(t, newAddress, 2)
+	assert.Equal(t, address[0], newAddress[0])
+}
+
+func TestFakeDNSReloadStaleFile(t *testing.T) {
+	config := &FakeDnsPoolMulti{
+		Pools: []*FakeDnsPool{{
+			IpPool:          "240.0.0.0/12",
+			LruSize:         256,
+			PersistPath:     filepath.Join(t.TempDir(), "fakedns.txt"),
+			PersistInterval: 3600,
+		}},
+	}
+	fakeMulti, err := NewFakeDNSHolderMulti(config)
+	common.Must(err)
+	common.Must(fakeMulti.Start())
+	defer fakeMulti.Close()
+
+	// The file was saved when the IP was mapped to another domain, which has been evicted since.
+	address := fakeMulti.GetFakeIPForDomain("new.example.com")
+	common.Must(os.WriteFile(config.Pools[0].PersistPath, []byte(address[0].String()+" old.example.com\n"), 0o600))
+
+	common.Must(fakeMulti.Reload(config))
+	assert.Equal(t, "new.example.com", fakeMulti.GetDomainFromFakeDNS(address[0]))
+}
@@ -0,0 +1,144 @@
+package fakedns
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/task"
+)
+
+// defaultPersistInterval is the interval to save the mappings if not configured.
+const defaultPersistInterval = time.Minute
+
+// startPersistence restores the mappings from the persist file, and saves them to it periodically
+// afterwards. It does nothing if no persist file is configured.
+func (fkdns *Holder) startPersistence() error {
+	path := fkdns.config.GetPersistPath()
+	if path == "" {
+		return nil
+	}
+
+	if err := fkdns.load(path); err != nil {
+		newError("failed to load fake DNS mappings from ", path).Base(err).AtWarning().WriteToLog()
+	}
+
+	interval := defaultPersistInterval
+	if i := fkdns.config.GetPersistInterval(); i > 0 {
+		interval = time.Duration(i) * time.Second
+	}
+	fkdns.persist = &task.Periodic{
+		Interval: interval,
+		Execute: func() error {
+			// Keep saving even if the file is temporarily unavailable.
+			if err := fkdns.save(path); err != nil {
+				newError("failed to save fake DNS mappings to ", path).Base(err).AtWarning().WriteToLog()
+			}
+			return nil
+		},
+	}
+	return fkdns.persist.Start()
+}
+
+// stopPersistence stops saving the mappings periodically, and saves them for the last time if save is true.
+func (fkdns *Holder) stopPersistence(save bool) error {
+	if fkdns.persist == nil {
+		return nil
+	}
+	if err := fkdns.persist.Close(); err != nil {
+		return err
+	}
+	fkdns.persist = nil
+	if save {
+		return fkdns.save(fkdns.config.GetPersistPath())
+	}
+	return nil
+}
+
+// load restores the mappings from the file. Mappings out of the IP pool are ignored, so that the
+// file stays usable after the pool is changed.
+func (fkdns *Holder) load(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if fkdns.restore(fields[1], net.ParseAddress(fields[0])) {
+			count++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	newError("loaded ", count, " fake DNS mappings from ", path).AtInfo().WriteToLog()
+	return nil
+}
+
+// restore adds the mapping between domain and ip if ip is in the IP pool, and neither of them is
+// mapped already. It returns whether the mapping is added.
+func (fkdns *Holder) restore(domain string, ip net.Address) bool {
+	if !ip.Family().IsIP() || !fkdns.ipRange.Contains(ip.IP()) {
+		return false
+	}
+
+	fkdns.mu.Lock()
+	defer fkdns.mu.Unlock()
+	if _, ok := fkdns.domainToIP.PeekKeyFromValue(ip); ok {
+		return false
+	}
+	if _, ok := fkdns.domainToIP.Get(domain); ok {
+		return false
+	}
+	fkdns.domainToIP.Put(domain, ip)
+	return true
+}
+
+// save writes the mappings to the file, one "ip domain" pair per line from the least to the most
+// recently used. The file is replaced atomically, so that it is never left half written.
+func (fkdns *Holder) save(path string) error {
+	// The mappings are copied first, as they are locked during Range.
+	type mapping struct {
+		ip     net.Address
+		domain string
+	}
+	var mappings []mapping
+	fkdns.domainToIP.Range(func(key, value interface{}) bool {
+		mappings = append(mappings, mapping{ip: value.(net.Address), domain: key.(string)})
+		return true
+	})
+
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	for _, m := range mappings {
+		writer.WriteString(m.ip.IP().String())
+		writer.WriteByte(' ')
+		writer.WriteString(m.domain)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
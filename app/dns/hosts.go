@@ -1,9 +1,12 @@
 package dns
 
 import (
+	"time"
+
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/strmatcher"
+	"github.com/xtls/xray-core/common/task"
 	"github.com/xtls/xray-core/features"
 	"github.com/xtls/xray-core/features/dns"
 )
@@ -12,6 +15,8 @@ import (
 type StaticHosts struct {
 	ips      [][]net.Address
 	matchers *strmatcher.MatcherGroup
+	files    []*hostsFile
+	check    *task.Periodic
 }
 
 // NewStaticHosts creates a new StaticHosts instance.
@@ -67,6 +72,53 @@ func NewStaticHosts(hosts []*Config_HostMapping, legacy map[string]*net.IPOrDoma
 	return sh, nil
 }
 
+// loadFiles loads the hosts files, which are checked for changes at the given interval in seconds after started.
+func (h *StaticHosts) loadFiles(files []*Config_HostsFile, interval uint32) error {
+	for _, config := range files {
+		file, err := newHostsFile(config)
+		if err != nil {
+			return err
+		}
+		h.files = append(h.files, file)
+	}
+	if len(h.files) == 0 {
+		return nil
+	}
+
+	checkInterval := defaultHostsFileCheckInterval
+	if interval > 0 {
+		checkInterval = time.Duration(interval) * time.Second
+	}
+	h.check = &task.Periodic{
+		Interval: checkInterval,
+		Execute: func() error {
+			for _, file := range h.files {
+				if _, err := file.reload(); err != nil {
+					newError("hosts file is not reloaded").Base(err).AtWarning().WriteToLog()
+				}
+			}
+			return nil
+		},
+	}
+	return nil
+}
+
+// Start implements common.Runnable.
+func (h *StaticHosts) Start() error {
+	if h.check == nil {
+		return nil
+	}
+	return h.check.Start()
+}
+
+// Close implements common.Closable.
+func (h *StaticHosts) Close() error {
+	if h.check == nil {
+		return nil
+	}
+	return h.check.Close()
+}
+
 func filterIP(ips []net.Address, option dns.IPOption) []net.Address {
 	filtered := make([]net.Address, 0, len(ips))
 	for _, ip := range ips {
@@ -82,6 +134,9 @@ func (h *StaticHosts) lookupInternal(domain string) []net.Address {
 	for _, id := range h.matchers.Match(domain) {
 		ips = append(ips, h.ips[id]...)
 	}
+	for _, file := range h.files {
+		ips = append(ips, file.lookupInternal(domain)...)
+	}
 	return ips
 }
 
@@ -0,0 +1,155 @@
+package dns
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common/net"
+)
+
+// defaultHostsFileCheckInterval is the interval to check whether hosts files are changed if not configured.
+const defaultHostsFileCheckInterval = 10 * time.Second
+
+// hostsFile is a file of static hosts, which is reloaded once it is changed.
+type hostsFile struct {
+	sync.RWMutex
+	config *Config_HostsFile
+	hosts  *StaticHosts
+
+	// modTime and size are only accessed by reload.
+	modTime time.Time
+	size    int64
+}
+
+func newHostsFile(config *Config_HostsFile) (*hostsFile, error) {
+	if config.Format == Config_HostsFile_DomainList && len(config.Ip) == 0 && len(config.ProxiedDomain) == 0 {
+		return nil, newError("neither IP address nor proxied domain specified for domain list: ", config.Path)
+	}
+	f := &hostsFile{config: config}
+	if _, err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// reload loads the file again if it is changed since last loaded, and returns whether it is reloaded.
+// The hosts are kept if the file fails to load.
+func (f *hostsFile) reload() (bool, error) {
+	info, err := os.Stat(f.config.Path)
+	if err != nil {
+		return false, newError("failed to read hosts file ", f.config.Path).Base(err)
+	}
+	if f.hosts != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return false, nil
+	}
+
+	mappings, err := f.parse()
+	if err != nil {
+		return false, newError("failed to read hosts file ", f.config.Path).Base(err)
+	}
+	hosts, err := NewStaticHosts(mappings, nil)
+	if err != nil {
+		return false, newError("failed to load hosts file ", f.config.Path).Base(err)
+	}
+
+	f.Lock()
+	f.hosts = hosts
+	f.Unlock()
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	newError("loaded ", len(mappings), " static hosts from ", f.config.Path).AtInfo().WriteToLog()
+	return true, nil
+}
+
+func (f *hostsFile) parse() ([]*Config_HostMapping, error) {
+	file, err := os.Open(f.config.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var mappings []*Config_HostMapping
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch f.config.Format {
+		case Config_HostsFile_Hosts:
+			mappings = append(mappings, parseHostsLine(fields)...)
+		case Config_HostsFile_DomainList:
+			if mapping := f.parseDomainListLine(fields[0]); mapping != nil {
+				mappings = append(mappings, mapping)
+			}
+		default:
+			return nil, newError("unknown hosts file format ", f.config.Format)
+		}
+	}
+	return mappings, scanner.Err()
+}
+
+// parseHostsLine parses a line of /etc/hosts, which is an IP followed by its host names.
+func parseHostsLine(fields []string) []*Config_HostMapping {
+	ip := net.ParseIP(fields[0])
+	if ip == nil {
+		// Such as IPv6 addresses with zone, which can't be used in answers.
+		newError("ignored invalid IP in hosts file: ", fields[0]).AtDebug().WriteToLog()
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	mappings := make([]*Config_HostMapping, 0, len(fields)-1)
+	for _, name := range fields[1:] {
+		mappings = append(mappings, &Config_HostMapping{
+			Type:   DomainMatchingType_Full,
+			Domain: name,
+			Ip:     [][]byte{ip},
+		})
+	}
+	return mappings
+}
+
+// parseDomainListLine parses a line of domain list, which is a domain optionally prefixed with its matching type.
+func (f *hostsFile) parseDomainListLine(entry string) *Config_HostMapping {
+	mapping := &Config_HostMapping{
+		Type:          DomainMatchingType_Subdomain,
+		Domain:        entry,
+		Ip:            f.config.Ip,
+		ProxiedDomain: f.config.ProxiedDomain,
+	}
+	switch {
+	case strings.HasPrefix(entry, "domain:"):
+		mapping.Domain = entry[7:]
+	case strings.HasPrefix(entry, "full:"):
+		mapping.Type = DomainMatchingType_Full
+		mapping.Domain = entry[5:]
+	case strings.HasPrefix(entry, "keyword:"):
+		mapping.Type = DomainMatchingType_Keyword
+		mapping.Domain = entry[8:]
+	case strings.HasPrefix(entry, "regexp:"):
+		mapping.Type = DomainMatchingType_Regex
+		mapping.Domain = entry[7:]
+	}
+	if len(mapping.Domain) == 0 {
+		return nil
+	}
+	return mapping
+}
+
+func (f *hostsFile) lookupInternal(domain string) []net.Address {
+	f.RLock()
+	defer f.RUnlock()
+
+	return f.hosts.lookupInternal(domain)
+}
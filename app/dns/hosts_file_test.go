@@ -0,0 +1,92 @@
+package dns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/features/dns"
+)
+
+var ipv46Option = dns.IPOption{IPv4Enable: true, IPv6Enable: true}
+
+func TestHostsFileHosts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+	common.Must(os.WriteFile(path, []byte(`# comment
+127.0.0.1 localhost
+::1       localhost ip6-localhost # loopback
+fe80::1%lo0 localhost
+10.0.0.1  nas.lan
+`), 0o644))
+
+	hosts, err := NewStaticHosts(nil, nil)
+	common.Must(err)
+	common.Must(hosts.loadFiles([]*Config_HostsFile{{Path: path}}, 0))
+
+	if r := cmp.Diff(hosts.Lookup("localhost", ipv46Option), []net.Address{net.LocalHostIP, net.LocalHostIPv6}); r != "" {
+		t.Error(r)
+	}
+	if r := cmp.Diff(hosts.Lookup("nas.lan", ipv46Option), []net.Address{net.ParseAddress("10.0.0.1")}); r != "" {
+		t.Error(r)
+	}
+	if ips := hosts.Lookup("sub.nas.lan", ipv46Option); ips != nil {
+		t.Error("expect hosts to match full domains, but got ", ips)
+	}
+
+	// The file is reloaded once changed.
+	common.Must(os.WriteFile(path, []byte("10.0.0.2 nas.lan\n"), 0o644))
+	reloaded, err := hosts.files[0].reload()
+	common.Must(err)
+	if !reloaded {
+		t.Error("expect changed hosts file to be reloaded")
+	}
+	if r := cmp.Diff(hosts.Lookup("nas.lan", ipv46Option), []net.Address{net.ParseAddress("10.0.0.2")}); r != "" {
+		t.Error(r)
+	}
+	if ips := hosts.Lookup("localhost", ipv46Option); ips != nil {
+		t.Error("expect removed host not to be found, but got ", ips)
+	}
+
+	// The hosts are kept if the file is missing.
+	common.Must(os.Remove(path))
+	if _, err := hosts.files[0].reload(); err == nil {
+		t.Error("expect error of missing hosts file")
+	}
+	if r := cmp.Diff(hosts.Lookup("nas.lan", ipv46Option), []net.Address{net.ParseAddress("10.0.0.2")}); r != "" {
+		t.Error(r)
+	}
+}
+
+func TestHostsFileDomainList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ads.txt")
+	common.Must(os.WriteFile(path, []byte(`ads.example.com
+full:tracker.example.org
+keyword:doubleclick
+`), 0o644))
+
+	hosts, err := NewStaticHosts(nil, nil)
+	common.Must(err)
+	common.Must(hosts.loadFiles([]*Config_HostsFile{{
+		Path:   path,
+		Format: Config_HostsFile_DomainList,
+		Ip:     [][]byte{{0, 0, 0, 0}},
+	}}, 0))
+
+	for _, domain := range []string{"ads.example.com", "www.ads.example.com", "tracker.example.org", "ad.doubleclick.net"} {
+		if r := cmp.Diff(hosts.Lookup(domain, ipv46Option), []net.Address{net.AnyIP}); r != "" {
+			t.Error(domain, r)
+		}
+	}
+	for _, domain := range []string{"example.com", "www.tracker.example.org"} {
+		if ips := hosts.Lookup(domain, ipv46Option); ips != nil {
+			t.Error("expect ", domain, " not to be found, but got ", ips)
+		}
+	}
+
+	if err := hosts.loadFiles([]*Config_HostsFile{{Path: path, Format: Config_HostsFile_DomainList}}, 0); err == nil {
+		t.Error("expect error of domain list without address")
+	}
+}
@@ -21,6 +21,8 @@ type Server interface {
 	Name() string
 	// QueryIP sends IP queries to its configured server.
 	QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns.IPOption, disableCache bool) ([]net.IP, error)
+	// Close releases the connections and cache of the server.
+	Close() error
 }
 
 // Client is the interface for DNS client.
@@ -28,14 +30,19 @@ type Client struct {
 	server       Server
 	clientIP     net.IP
 	skipFallback bool
+	timeout      time.Duration
 	domains      []string
 	expectIPs    []*router.GeoIPMatcher
+	conditions   []router.Condition
 }
 
 var errExpectedIPNonMatch = errors.New("expectIPs not match")
 
+// defaultQueryTimeout is the timeout of queries to name servers without timeout config.
+const defaultQueryTimeout = 4 * time.Second
+
 // NewServer creates a name server object according to the network destination url.
-func NewServer(dest net.Destination, dispatcher routing.Dispatcher) (Server, error) {
+func NewServer(dest net.Destination, dispatcher routing.Dispatcher, cacheConfig *CacheConfig) (Server, error) {
 	if address := dest.Address; address.Family().IsDomain() {
 		u, err := url.Parse(address.Domain())
 		if err != nil {
@@ -45,15 +52,19 @@ func NewServer(dest net.Destination, dispatcher routing.Dispatcher) (Server, err
 		case strings.EqualFold(u.String(), "localhost"):
 			return NewLocalNameServer(), nil
 		case strings.EqualFold(u.Scheme, "https"): // DOH Remote mode
-			return NewDoHNameServer(u, dispatcher)
+			return NewDoHNameServer(u, dispatcher, cacheConfig)
 		case strings.EqualFold(u.Scheme, "https+local"): // DOH Local mode
-			return NewDoHLocalNameServer(u), nil
+			return NewDoHLocalNameServer(u, cacheConfig), nil
 		case strings.EqualFold(u.Scheme, "quic+local"): // DNS-over-QUIC Local mode
-			return NewQUICNameServer(u)
+			return NewQUICNameServer(u, cacheConfig)
 		case strings.EqualFold(u.Scheme, "tcp"): // DNS-over-TCP Remote mode
-			return NewTCPNameServer(u, dispatcher)
+			return NewTCPNameServer(u, dispatcher, cacheConfig)
 		case strings.EqualFold(u.Scheme, "tcp+local"): // DNS-over-TCP Local mode
-			return NewTCPLocalNameServer(u)
+			return NewTCPLocalNameServer(u, cacheConfig)
+		case strings.EqualFold(u.Scheme, "tls"): // DNS-over-TLS Remote mode
+			return NewTLSNameServer(u, dispatcher, cacheConfig)
+		case strings.EqualFold(u.Scheme, "tls+local"): // DNS-over-TLS Local mode
+			return NewTLSLocalNameServer(u, cacheConfig)
 		case strings.EqualFold(u.String(), "fakedns"):
 			return NewFakeDNSServer(), nil
 		}
@@ -62,18 +73,18 @@ func NewServer(dest net.Destination, dispatcher routing.Dispatcher) (Server, err
 		dest.Network = net.Network_UDP
 	}
 	if dest.Network == net.Network_UDP { // UDP classic DNS mode
-		return NewClassicNameServer(dest, dispatcher), nil
+		return NewClassicNameServer(dest, dispatcher, cacheConfig), nil
 	}
 	return nil, newError("No available name server could be created from ", dest).AtWarning()
 }
 
 // NewClient creates a DNS client managing a name server with client IP, domain rules and expected IPs.
-func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container router.GeoIPMatcherContainer, matcherInfos *[]*DomainMatcherInfo, updateDomainRule func(strmatcher.Matcher, int, []*DomainMatcherInfo) error) (*Client, error) {
+func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container router.GeoIPMatcherContainer, matcherInfos *[]*DomainMatcherInfo, updateDomainRule func(strmatcher.Matcher, int, []*DomainMatcherInfo) error, cacheConfig *CacheConfig) (*Client, error) {
 	client := &Client{}
 
 	err := core.RequireFeatures(ctx, func(dispatcher routing.Dispatcher) error {
 		// Create a new server for each client for now
-		server, err := NewServer(ns.Address.AsDestination(), dispatcher)
+		server, err := NewServer(ns.Address.AsDestination(), dispatcher, cacheConfig)
 		if err != nil {
 			return newError("failed to create nameserver").Base(err).AtWarning()
 		}
@@ -149,18 +160,25 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 		client.server = server
 		client.clientIP = clientIP
 		client.skipFallback = ns.SkipFallback
+		client.timeout = time.Duration(ns.TimeoutMs) * time.Millisecond
 		client.domains = rules
 		client.expectIPs = matchers
+		if len(ns.InboundTag) > 0 {
+			client.conditions = append(client.conditions, router.NewInboundTagMatcher(ns.InboundTag))
+		}
+		if len(ns.UserEmail) > 0 {
+			client.conditions = append(client.conditions, router.NewUserMatcher(ns.UserEmail))
+		}
 		return nil
 	})
 	return client, err
 }
 
 // NewSimpleClient creates a DNS client with a simple destination.
-func NewSimpleClient(ctx context.Context, endpoint *net.Endpoint, clientIP net.IP) (*Client, error) {
+func NewSimpleClient(ctx context.Context, endpoint *net.Endpoint, clientIP net.IP, cacheConfig *CacheConfig) (*Client, error) {
 	client := &Client{}
 	err := core.RequireFeatures(ctx, func(dispatcher routing.Dispatcher) error {
-		server, err := NewServer(endpoint.AsDestination(), dispatcher)
+		server, err := NewServer(endpoint.AsDestination(), dispatcher, cacheConfig)
 		if err != nil {
 			return newError("failed to create nameserver").Base(err).AtWarning()
 		}
@@ -186,9 +204,29 @@ func (c *Client) Name() string {
 	return c.server.Name()
 }
 
+// Close closes the server of the client.
+func (c *Client) Close() error {
+	return c.server.Close()
+}
+
+// Allows returns whether the name server can be used for queries of the session. Name servers
+// restricted to inbounds or users are not used for queries without session.
+func (c *Client) Allows(ctx routing.Context) bool {
+	for _, condition := range c.conditions {
+		if ctx == nil || !condition.Apply(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
 // QueryIP sends DNS query to the name server with the client's IP.
 func (c *Client) QueryIP(ctx context.Context, domain string, option dns.IPOption, disableCache bool) ([]net.IP, error) {
-	ctx, cancel := context.WithTimeout(ctx, 4*time.Second)
+	timeout := c.timeout
+	if timeout == 0 {
+		timeout = defaultQueryTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	ips, err := c.server.QueryIP(ctx, domain, c.clientIP, option, disableCache)
 	cancel()
 
@@ -40,6 +40,11 @@ func (s *LocalNameServer) Name() string {
 	return "localhost"
 }
 
+// Close implements Server.
+func (s *LocalNameServer) Close() error {
+	return nil
+}
+
 // NewLocalNameServer creates localdns server object for directly lookup in system DNS.
 func NewLocalNameServer() *LocalNameServer {
 	newError("DNS: created localhost client").AtInfo().WriteToLog()
@@ -0,0 +1,389 @@
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/net/cnc"
+	"github.com/xtls/xray-core/common/protocol/dns"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/common/signal/pubsub"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/transport/internet"
+	"github.com/xtls/xray-core/transport/internet/tls"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// NextProtoDoT is the ALPN token of DNS over TLS.
+const NextProtoDoT = "dot"
+
+// dotIdleTimeout is the time after which an idle connection is closed.
+const dotIdleTimeout = time.Minute * 2
+
+// TLSNameServer implemented DNS over TLS (RFC7858). Queries are pipelined over a single connection,
+// which is reused until it is idle or closed by the server.
+type TLSNameServer struct {
+	sync.RWMutex
+	name            string
+	destination     *net.Destination
+	tlsConfig       *tls.Config
+	cacheController *CacheController
+	reqID           uint32
+	dial            func(context.Context) (net.Conn, error)
+
+	connAccess sync.Mutex
+	conn       *dotConn
+}
+
+// dotConn is a DNS over TLS connection with its queries waiting for responses.
+type dotConn struct {
+	net.Conn
+	writeAccess sync.Mutex
+	idle        *time.Timer
+
+	access  sync.Mutex
+	pending map[uint16]*dnsRequest
+	closed  bool
+}
+
+// NewTLSNameServer creates DNS over TLS server object for remote resolving.
+func NewTLSNameServer(url *url.URL, dispatcher routing.Dispatcher, cacheConfig *CacheConfig) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, "DOT", cacheConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		link, err := dispatcher.Dispatch(toDnsContext(ctx, s.destination.String()), *s.destination)
+		if err != nil {
+			return nil, err
+		}
+
+		cc := common.ChainedClosable{}
+		if cw, ok := link.Writer.(common.Closable); ok {
+			cc = append(cc, cw)
+		}
+		if cr, ok := link.Reader.(common.Closable); ok {
+			cc = append(cc, cr)
+		}
+		return cnc.NewConnection(
+			cnc.ConnectionInputMulti(link.Writer),
+			cnc.ConnectionOutputMulti(link.Reader),
+			cnc.ConnectionOnClose(cc),
+		), nil
+	}
+
+	return s, nil
+}
+
+// NewTLSLocalNameServer creates DNS over TLS client object for local resolving
+func NewTLSLocalNameServer(url *url.URL, cacheConfig *CacheConfig) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, "DOTL", cacheConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		return internet.DialSystem(ctx, *s.destination, nil)
+	}
+
+	return s, nil
+}
+
+func baseTLSNameServer(url *url.URL, prefix string, cacheConfig *CacheConfig) (*TLSNameServer, error) {
+	var err error
+	port := net.Port(853)
+	if url.Port() != "" {
+		port, err = net.PortFromString(url.Port())
+		if err != nil {
+			return nil, err
+		}
+	}
+	dest := net.TCPDestination(net.ParseAddress(url.Hostname()), port)
+
+	s := &TLSNameServer{
+		destination: &dest,
+		tlsConfig:   &tls.Config{ServerName: url.Hostname()},
+		name:        prefix + "//" + dest.NetAddr(),
+	}
+	s.cacheController = NewCacheController(s.name, cacheConfig)
+
+	return s, nil
+}
+
+// Name implements Server.
+func (s *TLSNameServer) Name() string {
+	return s.name
+}
+
+// Close implements Server.
+func (s *TLSNameServer) Close() error {
+	s.connAccess.Lock()
+	if s.conn != nil {
+		s.conn.close()
+		s.conn = nil
+	}
+	s.connAccess.Unlock()
+
+	return s.cacheController.Close()
+}
+
+func (s *TLSNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
+	elapsed := time.Since(req.start)
+
+	var rec record
+	switch req.reqType {
+	case dnsmessage.TypeA:
+		rec.A = ipRec
+	case dnsmessage.TypeAAAA:
+		addr := make([]net.Address, 0)
+		for _, ip := range ipRec.IP {
+			if len(ip.IP()) == net.IPv6len {
+				addr = append(addr, ip)
+			}
+		}
+		ipRec.IP = addr
+		rec.AAAA = ipRec
+	}
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
+
+	s.cacheController.updateIP(req.domain, &rec)
+}
+
+func (s *TLSNameServer) newReqID() uint16 {
+	return uint16(atomic.AddUint32(&s.reqID, 1))
+}
+
+// getConnection returns the current connection, or opens a new one if there is none.
+func (s *TLSNameServer) getConnection(ctx context.Context) (*dotConn, error) {
+	s.connAccess.Lock()
+	defer s.connAccess.Unlock()
+
+	if s.conn != nil && !s.conn.isClosed() {
+		return s.conn, nil
+	}
+
+	// The connection is reused by later queries, so it's dialed with a context which isn't canceled
+	// with this query, whose deadline only applies to the handshake.
+	dialCtx := toRefreshContext(ctx)
+	dialCtx = session.ContextWithContent(dialCtx, session.ContentFromContext(ctx))
+	rawConn, err := s.dial(dialCtx)
+	if err != nil {
+		return nil, err
+	}
+	conn := tls.Client(rawConn, s.tlsConfig.GetTLSConfig(tls.WithNextProto(NextProtoDoT))).(*tls.Conn)
+	if err := conn.HandshakeContext(ctx); err != nil {
+		rawConn.Close()
+		return nil, newError("failed to handshake with ", s.name).Base(err)
+	}
+
+	s.conn = &dotConn{
+		Conn:    conn,
+		pending: make(map[uint16]*dnsRequest),
+	}
+	s.conn.idle = time.AfterFunc(dotIdleTimeout, s.conn.close)
+	go s.readResponses(s.conn)
+	return s.conn, nil
+}
+
+// readResponses reads responses from conn until it is closed.
+func (s *TLSNameServer) readResponses(conn *dotConn) {
+	defer conn.close()
+
+	for {
+		var length uint16
+		if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+			if err != io.EOF {
+				newError(s.name, " failed to read response length").Base(err).AtDebug().WriteToLog()
+			}
+			return
+		}
+		resp := make([]byte, length)
+		if _, err := io.ReadFull(conn, resp); err != nil {
+			newError(s.name, " failed to read response").Base(err).AtDebug().WriteToLog()
+			return
+		}
+		rec, err := parseResponse(resp)
+		if err != nil {
+			newError("failed to parse DNS over TLS response").Base(err).AtError().WriteToLog()
+			continue
+		}
+		if req := conn.finish(rec.ReqID); req != nil {
+			s.updateIP(req, rec)
+		}
+	}
+}
+
+func (c *dotConn) isClosed() bool {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	return c.closed
+}
+
+func (c *dotConn) close() {
+	c.access.Lock()
+	c.idle.Stop()
+	c.closed = true
+	c.pending = nil
+	c.access.Unlock()
+
+	c.Close()
+}
+
+// send writes the query of req, which waits for its response afterwards.
+func (c *dotConn) send(req *dnsRequest, b *buf.Buffer) error {
+	c.access.Lock()
+	if c.closed {
+		c.access.Unlock()
+		return newError("connection closed")
+	}
+	c.pending[req.msg.ID] = req
+	c.idle.Reset(dotIdleTimeout)
+	c.access.Unlock()
+
+	c.writeAccess.Lock()
+	defer c.writeAccess.Unlock()
+
+	reqBuf := buf.New()
+	defer reqBuf.Release()
+	binary.Write(reqBuf, binary.BigEndian, uint16(b.Len()))
+	reqBuf.Write(b.Bytes())
+	if _, err := c.Write(reqBuf.Bytes()); err != nil {
+		c.finish(req.msg.ID)
+		return err
+	}
+	return nil
+}
+
+// finish removes the query of the given ID from pending ones.
+func (c *dotConn) finish(id uint16) *dnsRequest {
+	c.access.Lock()
+	defer c.access.Unlock()
+
+	req := c.pending[id]
+	delete(c.pending, id)
+	return req
+}
+
+func (s *TLSNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
+	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+
+	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+
+	var deadline time.Time
+	if d, ok := ctx.Deadline(); ok {
+		deadline = d
+	} else {
+		deadline = time.Now().Add(time.Second * 5)
+	}
+
+	for _, req := range reqs {
+		go func(r *dnsRequest) {
+			dnsCtx := ctx
+
+			if inbound := session.InboundFromContext(ctx); inbound != nil {
+				dnsCtx = session.ContextWithInbound(dnsCtx, inbound)
+			}
+
+			dnsCtx = session.ContextWithContent(dnsCtx, &session.Content{
+				Protocol:       "dns",
+				SkipDNSResolve: true,
+			})
+
+			var cancel context.CancelFunc
+			dnsCtx, cancel = context.WithDeadline(dnsCtx, deadline)
+			defer cancel()
+
+			b, err := dns.PackMessage(r.msg)
+			if err != nil {
+				newError("failed to pack dns query").Base(err).AtError().WriteToLog()
+				return
+			}
+			defer b.Release()
+
+			conn, err := s.getConnection(dnsCtx)
+			if err != nil {
+				newError("failed to dial namesever").Base(err).AtError().WriteToLog()
+				return
+			}
+			if err := conn.send(r, b); err != nil {
+				newError("failed to send query").Base(err).AtError().WriteToLog()
+				conn.close()
+			}
+		}(req)
+	}
+}
+
+// QueryIP implements Server.
+func (s *TLSNameServer) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool) ([]net.IP, error) {
+	fqdn := Fqdn(domain)
+
+	if disableCache {
+		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
+	} else {
+		ips, refresh, err := s.cacheController.lookupIPsForDomain(fqdn, option)
+		if err != errRecordNotFound {
+			if refresh {
+				newError(s.name, " refreshing ", domain, " in background").AtDebug().WriteToLog()
+				go s.sendQuery(toRefreshContext(ctx), fqdn, clientIP, option)
+			}
+			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
+			log.Record(&log.DNSLog{Server: s.name, Domain: domain, Result: ips, Status: log.DNSCacheHit, Elapsed: 0, Error: err})
+			return ips, err
+		}
+	}
+
+	// ipv4 and ipv6 belong to different subscription groups
+	var sub4, sub6 *pubsub.Subscriber
+	if option.IPv4Enable {
+		sub4 = s.cacheController.pub.Subscribe(fqdn + "4")
+		defer sub4.Close()
+	}
+	if option.IPv6Enable {
+		sub6 = s.cacheController.pub.Subscribe(fqdn + "6")
+		defer sub6.Close()
+	}
+	done := make(chan interface{})
+	go func() {
+		if sub4 != nil {
+			select {
+			case <-sub4.Wait():
+			case <-ctx.Done():
+			}
+		}
+		if sub6 != nil {
+			select {
+			case <-sub6.Wait():
+			case <-ctx.Done():
+			}
+		}
+		close(done)
+	}()
+	s.sendQuery(ctx, fqdn, clientIP, option)
+	start := time.Now()
+
+	for {
+		ips, err := s.cacheController.findIPsForDomain(fqdn, option)
+		if err != errRecordNotFound {
+			log.Record(&log.DNSLog{Server: s.name, Domain: domain, Result: ips, Status: log.DNSQueried, Elapsed: time.Since(start), Error: err})
+			return ips, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-done:
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package dns
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"encoding/binary"
+	"io"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol/tls/cert"
+	"github.com/xtls/xray-core/core"
+	dns_feature "github.com/xtls/xray-core/features/dns"
+	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/transport"
+	"github.com/xtls/xray-core/transport/internet/tls"
+	"github.com/xtls/xray-core/transport/pipe"
+)
+
+// serveDoT answers every A query with 1.2.3.4 on connections of listener.
+func serveDoT(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			for {
+				var length uint16
+				if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+					return
+				}
+				b := make([]byte, length)
+				if _, err := io.ReadFull(conn, b); err != nil {
+					return
+				}
+				req := new(dns.Msg)
+				if err := req.Unpack(b); err != nil {
+					return
+				}
+				ans := new(dns.Msg).SetReply(req)
+				if q := req.Question[0]; q.Qtype == dns.TypeA {
+					ans.Answer = append(ans.Answer, common.Must2(dns.NewRR(q.Name+" 60 IN A 1.2.3.4")).(dns.RR))
+				}
+				resp, err := ans.Pack()
+				if err != nil {
+					return
+				}
+				binary.Write(conn, binary.BigEndian, uint16(len(resp)))
+				if _, err := conn.Write(resp); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// dotDispatcher dispatches links to the DoT server, which are closed once the context of the
+// dispatch is done, like what outbounds do.
+type dotDispatcher struct {
+	routing.Dispatcher
+	address    string
+	dispatches int32
+}
+
+func (d *dotDispatcher) Dispatch(ctx context.Context, dest net.Destination) (*transport.Link, error) {
+	atomic.AddInt32(&d.dispatches, 1)
+	conn, err := net.Dial("tcp", d.address)
+	if err != nil {
+		return nil, err
+	}
+	uplinkReader, uplinkWriter := pipe.New()
+	downlinkReader, downlinkWriter := pipe.New()
+	go buf.Copy(uplinkReader, buf.NewWriter(conn))
+	go buf.Copy(buf.NewReader(conn), downlinkWriter)
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+		uplinkReader.Interrupt()
+		downlinkWriter.Close()
+	}()
+	return &transport.Link{Reader: downlinkReader, Writer: uplinkWriter}, nil
+}
+
+func TestTLSNameServerReuseConnection(t *testing.T) {
+	certificate := cert.MustGenerate(nil, cert.DNSNames("dns.example"))
+	certPEM, keyPEM := certificate.ToPEM()
+	keyPair, err := gotls.X509KeyPair(certPEM, keyPEM)
+	common.Must(err)
+	listener, err := gotls.Listen("tcp", "127.0.0.1:0", &gotls.Config{
+		Certificates: []gotls.Certificate{keyPair},
+	})
+	common.Must(err)
+	defer listener.Close()
+	go serveDoT(listener)
+
+	instance, err := core.New(&core.Config{})
+	common.Must(err)
+	dispatcher := &dotDispatcher{address: listener.Addr().String()}
+	s, err := NewTLSNameServer(common.Must2(url.Parse("tls://dns.example")).(*url.URL), dispatcher, nil)
+	common.Must(err)
+	defer s.Close()
+	s.tlsConfig.DisableSystemRoot = true
+	s.tlsConfig.Certificate = []*tls.Certificate{{Certificate: certPEM, Usage: tls.Certificate_AUTHORITY_VERIFY}}
+
+	for _, domain := range []string{"example.com", "example.org"} {
+		ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), core.XrayKey(1), instance), time.Second*5)
+		ips, err := s.QueryIP(ctx, domain, net.IP(nil), dns_feature.IPOption{IPv4Enable: true}, true)
+		cancel()
+		common.Must(err)
+		if len(ips) != 1 || ips[0].String() != "1.2.3.4" {
+			t.Error("unexpected ips of ", domain, ": ", ips)
+		}
+	}
+	if n := atomic.LoadInt32(&dispatcher.dispatches); n != 1 {
+		t.Error("expect the connection to be reused, but dispatched ", n, " times")
+	}
+}
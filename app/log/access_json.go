@@ -0,0 +1,117 @@
+package log
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/serial"
+)
+
+// accessField is a field of JSON access log.
+type accessField struct {
+	name  string
+	value func(*log.AccessMessage) interface{}
+}
+
+// accessFields lists all fields of JSON access log in their default order.
+var accessFields = []accessField{
+	{"from", func(m *log.AccessMessage) interface{} { return serial.ToString(m.From) }},
+	{"to", func(m *log.AccessMessage) interface{} { return serial.ToString(m.To) }},
+	{"status", func(m *log.AccessMessage) interface{} { return string(m.Status) }},
+	{"reason", func(m *log.AccessMessage) interface{} { return serial.ToString(m.Reason) }},
+	{"email", func(m *log.AccessMessage) interface{} { return m.Email }},
+	{"detour", func(m *log.AccessMessage) interface{} { return m.Detour }},
+	{"sessionId", func(m *log.AccessMessage) interface{} { return m.SessionID }},
+	{"inboundTag", func(m *log.AccessMessage) interface{} { return m.InboundTag }},
+	{"outboundTag", func(m *log.AccessMessage) interface{} { return m.OutboundTag }},
+	{"protocol", func(m *log.AccessMessage) interface{} { return m.Protocol }},
+	{"domain", func(m *log.AccessMessage) interface{} { return m.Domain }},
+	{"uplink", func(m *log.AccessMessage) interface{} { return m.Uplink }},
+	{"downlink", func(m *log.AccessMessage) interface{} { return m.Downlink }},
+	// Duration of the session in milliseconds.
+	{"duration", func(m *log.AccessMessage) interface{} { return m.Duration.Milliseconds() }},
+}
+
+// parseAccessFields returns the JSON access log fields of the given names.
+// All fields are returned if names is empty.
+func parseAccessFields(names []string) ([]accessField, error) {
+	if len(names) == 0 {
+		return accessFields, nil
+	}
+	fields := make([]accessField, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, field := range accessFields {
+			if field.name == name {
+				fields = append(fields, field)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, newError("unknown access log field: ", name)
+		}
+	}
+	return fields, nil
+}
+
+// jsonAccessMessage renders an access message as a single JSON object.
+type jsonAccessMessage struct {
+	time   time.Time
+	msg    log.AccessMessage
+	fields []accessField
+}
+
+func newJSONAccessMessage(msg *log.AccessMessage, fields []accessField) *jsonAccessMessage {
+	// The message is copied, as it may be changed by its session before being written.
+	return &jsonAccessMessage{
+		time:   time.Now(),
+		msg:    *msg,
+		fields: fields,
+	}
+}
+
+func (m *jsonAccessMessage) String() string {
+	builder := &strings.Builder{}
+	builder.WriteString(`{"time":`)
+	writeJSONValue(builder, m.time.Format(time.RFC3339Nano))
+	for _, field := range m.fields {
+		builder.WriteByte(',')
+		writeJSONValue(builder, field.name)
+		builder.WriteByte(':')
+		writeJSONValue(builder, field.value(&m.msg))
+	}
+	builder.WriteByte('}')
+	return builder.String()
+}
+
+// jsonMessage renders other messages in JSON access log, such as DNS log.
+type jsonMessage struct {
+	time time.Time
+	msg  string
+}
+
+func newJSONMessage(msg log.Message) *jsonMessage {
+	return &jsonMessage{
+		time: time.Now(),
+		msg:  msg.String(),
+	}
+}
+
+func (m *jsonMessage) String() string {
+	builder := &strings.Builder{}
+	builder.WriteString(`{"time":`)
+	writeJSONValue(builder, m.time.Format(time.RFC3339Nano))
+	builder.WriteString(`,"message":`)
+	writeJSONValue(builder, m.msg)
+	builder.WriteByte('}')
+	return builder.String()
+}
+
+func writeJSONValue(builder *strings.Builder, v interface{}) {
+	// Strings and integers are always marshalled successfully.
+	b, _ := json.Marshal(v)
+	builder.Write(b)
+}
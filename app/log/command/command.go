@@ -4,13 +4,22 @@ package command
 
 import (
 	"context"
+	"net"
+	"strings"
+	"time"
 
 	"github.com/xtls/xray-core/app/log"
 	"github.com/xtls/xray-core/common"
+	clog "github.com/xtls/xray-core/common/log"
 	"github.com/xtls/xray-core/core"
 	grpc "google.golang.org/grpc"
 )
 
+const (
+	defaultTraceDuration = 300
+	maxTraceDuration     = 3600
+)
+
 type LoggerServer struct {
 	V *core.Instance
 }
@@ -30,6 +39,71 @@ func (s *LoggerServer) RestartLogger(ctx context.Context, request *RestartLogger
 	return &RestartLoggerResponse{}, nil
 }
 
+// SetLogLevel implements LoggerService.
+func (s *LoggerServer) SetLogLevel(ctx context.Context, request *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	logger, ok := s.V.GetFeature((*log.Instance)(nil)).(*log.Instance)
+	if !ok {
+		return nil, newError("unable to get logger instance")
+	}
+	if _, found := clog.Severity_name[int32(request.Level)]; !found || request.Level == clog.Severity_Unknown {
+		return nil, newError("invalid log level: ", request.Level)
+	}
+	logger.SetErrorLogLevel(request.Level)
+	return &SetLogLevelResponse{}, nil
+}
+
+// Trace implements LoggerService.
+func (s *LoggerServer) Trace(ctx context.Context, request *TraceRequest) (*TraceResponse, error) {
+	logger, ok := s.V.GetFeature((*log.Instance)(nil)).(*log.Instance)
+	if !ok {
+		return nil, newError("unable to get logger instance")
+	}
+	if len(request.Email) == 0 && len(request.InboundTag) == 0 && len(request.Source) == 0 {
+		return nil, newError("no session to trace")
+	}
+
+	filter := log.TraceFilter{
+		Email:      request.Email,
+		InboundTag: request.InboundTag,
+	}
+	if len(request.Source) > 0 {
+		source, err := parseSource(request.Source)
+		if err != nil {
+			return nil, newError("invalid source: ", request.Source).Base(err)
+		}
+		filter.Source = source
+	}
+
+	duration := request.Duration
+	if duration == 0 {
+		duration = defaultTraceDuration
+	}
+	if duration > maxTraceDuration {
+		duration = maxTraceDuration
+	}
+	expire := time.Now().Add(time.Duration(duration) * time.Second)
+	logger.Trace(filter, expire)
+	return &TraceResponse{
+		ExpireTime: expire.Unix(),
+	}, nil
+}
+
+// parseSource parses an IP or CIDR.
+func parseSource(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, ipNet, err := net.ParseCIDR(s)
+		return ipNet, err
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, newError("invalid IP")
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}, nil
+}
+
 func (s *LoggerServer) mustEmbedUnimplementedLoggerServiceServer() {}
 
 type service struct {
@@ -5,6 +5,7 @@ package log
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/log"
@@ -18,6 +19,12 @@ type Instance struct {
 	errorLogger  log.Handler
 	active       bool
 	dns          bool
+	accessFields []accessField
+	errorLevel   log.Severity
+
+	traceAccess    sync.RWMutex
+	traces         []*trace
+	tracedSessions map[uint32]time.Time
 }
 
 // New creates a new log.Instance based on the given config.
@@ -26,6 +33,16 @@ func New(ctx context.Context, config *Config) (*Instance, error) {
 		config: config,
 		active: false,
 		dns:    config.EnableDnsLog,
+
+		errorLevel:     config.ErrorLogLevel,
+		tracedSessions: make(map[uint32]time.Time),
+	}
+	if config.AccessLogFormat == AccessLogFormat_JSON {
+		fields, err := parseAccessFields(config.AccessLogFields)
+		if err != nil {
+			return nil, err
+		}
+		g.accessFields = fields
 	}
 	log.RegisterHandler(g)
 
@@ -41,7 +58,9 @@ func New(ctx context.Context, config *Config) (*Instance, error) {
 
 func (g *Instance) initAccessLogger() error {
 	handler, err := createHandler(g.config.AccessLogType, HandlerCreatorOptions{
-		Path: g.config.AccessLogPath,
+		Path:     g.config.AccessLogPath,
+		Plain:    g.config.AccessLogFormat == AccessLogFormat_JSON,
+		Rotation: g.config.AccessLogRotation,
 	})
 	if err != nil {
 		return err
@@ -52,7 +71,8 @@ func (g *Instance) initAccessLogger() error {
 
 func (g *Instance) initErrorLogger() error {
 	handler, err := createHandler(g.config.ErrorLogType, HandlerCreatorOptions{
-		Path: g.config.ErrorLogPath,
+		Path:     g.config.ErrorLogPath,
+		Rotation: g.config.ErrorLogRotation,
 	})
 	if err != nil {
 		return err
@@ -102,15 +122,29 @@ func (g *Instance) Handle(msg log.Message) {
 
 	switch msg := msg.(type) {
 	case *log.AccessMessage:
-		if g.accessLogger != nil {
+		if msg.Status == log.AccessAccepted {
+			g.traceSession(msg)
+		}
+		if g.accessLogger == nil {
+			return
+		}
+		switch {
+		case g.accessFields != nil:
+			g.accessLogger.Handle(newJSONAccessMessage(msg, g.accessFields))
+		case msg.Status != log.AccessClosed:
+			// Text access log has one line per accepted session.
 			g.accessLogger.Handle(msg)
 		}
 	case *log.DNSLog:
 		if g.dns && g.accessLogger != nil {
-			g.accessLogger.Handle(msg)
+			if g.accessFields != nil {
+				g.accessLogger.Handle(newJSONMessage(msg))
+			} else {
+				g.accessLogger.Handle(msg)
+			}
 		}
 	case *log.GeneralMessage:
-		if g.errorLogger != nil && msg.Severity <= g.config.ErrorLogLevel {
+		if g.errorLogger != nil && (msg.Severity <= g.errorLevel || g.isTraced(msg.SessionID)) {
 			g.errorLogger.Handle(msg)
 		}
 	default:
@@ -118,6 +152,14 @@ func (g *Instance) Handle(msg log.Message) {
 	}
 }
 
+// SetErrorLogLevel changes the severity level of error log.
+func (g *Instance) SetErrorLogLevel(level log.Severity) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.errorLevel = level
+}
+
 // Close implements common.Closable.Close().
 func (g *Instance) Close() error {
 	newError("Logger closing").AtDebug().WriteToLog()
@@ -2,6 +2,7 @@ package log
 
 import (
 	"sync"
+	"time"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/log"
@@ -9,6 +10,10 @@ import (
 
 type HandlerCreatorOptions struct {
 	Path string
+	// Plain omits the date and time prefix of each line.
+	Plain bool
+	// Rotation of the log file, if not nil.
+	Rotation *LogRotation
 }
 
 type HandlerCreator func(LogType, HandlerCreatorOptions) (log.Handler, error)
@@ -42,14 +47,32 @@ func createHandler(logType LogType, options HandlerCreatorOptions) (log.Handler,
 
 func init() {
 	common.Must(RegisterHandlerCreator(LogType_Console, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
-		return log.NewLogger(log.CreateStdoutLogWriter()), nil
+		creator := log.CreateStdoutLogWriter()
+		if options.Plain {
+			creator = log.CreatePlainLogWriter(creator)
+		}
+		return log.NewLogger(creator), nil
 	}))
 
 	common.Must(RegisterHandlerCreator(LogType_File, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
-		creator, err := log.CreateFileLogWriter(options.Path)
+		var creator log.WriterCreator
+		var err error
+		if options.Rotation != nil {
+			creator, err = log.CreateRotatingFileLogWriter(options.Path, log.RotateOptions{
+				MaxSize:    options.Rotation.MaxSize,
+				Interval:   time.Duration(options.Rotation.Interval) * time.Second,
+				MaxBackups: int(options.Rotation.MaxBackups),
+				Compress:   options.Rotation.Compress,
+			})
+		} else {
+			creator, err = log.CreateFileLogWriter(options.Path)
+		}
 		if err != nil {
 			return nil, err
 		}
+		if options.Plain {
+			creator = log.CreatePlainLogWriter(creator)
+		}
 		return log.NewLogger(creator), nil
 	}))
 
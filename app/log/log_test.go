@@ -2,9 +2,12 @@ package log_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 	"github.com/xtls/xray-core/app/log"
 	"github.com/xtls/xray-core/common"
 	clog "github.com/xtls/xray-core/common/log"
@@ -50,3 +53,107 @@ func TestCustomLogHandler(t *testing.T) {
 
 	common.Must(logger.Close())
 }
+
+func TestJSONAccessLog(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	var loggedValue []string
+
+	mockHandler := mocks.NewLogHandler(mockCtl)
+	mockHandler.EXPECT().Handle(gomock.Any()).AnyTimes().DoAndReturn(func(msg clog.Message) {
+		loggedValue = append(loggedValue, msg.String())
+	})
+
+	log.RegisterHandlerCreator(log.LogType_Console, func(lt log.LogType, options log.HandlerCreatorOptions) (clog.Handler, error) {
+		if !options.Plain {
+			t.Error("expected plain log writer for json access log")
+		}
+		return mockHandler, nil
+	})
+
+	logger, err := log.New(context.Background(), &log.Config{
+		ErrorLogType:    log.LogType_None,
+		AccessLogType:   log.LogType_Console,
+		AccessLogFormat: log.AccessLogFormat_JSON,
+		AccessLogFields: []string{"to", "status", "outboundTag", "uplink", "duration"},
+	})
+	common.Must(err)
+	common.Must(logger.Start())
+
+	clog.Record(&clog.AccessMessage{
+		From:        "127.0.0.1:1080",
+		To:          "tcp:example.com:443",
+		Status:      clog.AccessClosed,
+		OutboundTag: "direct",
+		Uplink:      1024,
+		Duration:    1500 * time.Millisecond,
+	})
+
+	if len(loggedValue) != 1 {
+		t.Fatal("expected 1 log message, but actually ", loggedValue)
+	}
+	var record map[string]interface{}
+	common.Must(json.Unmarshal([]byte(loggedValue[0]), &record))
+	if _, found := record["time"]; !found {
+		t.Error("time not found in ", loggedValue[0])
+	}
+	delete(record, "time")
+	if r := cmp.Diff(record, map[string]interface{}{
+		"to":          "tcp:example.com:443",
+		"status":      "closed",
+		"outboundTag": "direct",
+		"uplink":      float64(1024),
+		"duration":    float64(1500),
+	}); r != "" {
+		t.Error(r)
+	}
+
+	common.Must(logger.Close())
+
+	if _, err := log.New(context.Background(), &log.Config{
+		AccessLogFormat: log.AccessLogFormat_JSON,
+		AccessLogFields: []string{"unknown"},
+	}); err == nil {
+		t.Error("expected error for unknown access log field")
+	}
+}
+
+func TestTraceSession(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	var loggedValue []string
+
+	mockHandler := mocks.NewLogHandler(mockCtl)
+	mockHandler.EXPECT().Handle(gomock.Any()).AnyTimes().DoAndReturn(func(msg clog.Message) {
+		loggedValue = append(loggedValue, msg.String())
+	})
+
+	log.RegisterHandlerCreator(log.LogType_Console, func(lt log.LogType, options log.HandlerCreatorOptions) (clog.Handler, error) {
+		return mockHandler, nil
+	})
+
+	logger, err := log.New(context.Background(), &log.Config{
+		ErrorLogLevel: clog.Severity_Warning,
+		ErrorLogType:  log.LogType_Console,
+		AccessLogType: log.LogType_None,
+	})
+	common.Must(err)
+	common.Must(logger.Start())
+
+	logger.Trace(log.TraceFilter{Email: "traced@example.com"}, time.Now().Add(time.Minute))
+	clog.Record(&clog.AccessMessage{Status: clog.AccessAccepted, SessionID: 1, Email: "traced@example.com"})
+	clog.Record(&clog.AccessMessage{Status: clog.AccessAccepted, SessionID: 2, Email: "other@example.com"})
+	clog.Record(&clog.GeneralMessage{Severity: clog.Severity_Debug, Content: "traced", SessionID: 1})
+	clog.Record(&clog.GeneralMessage{Severity: clog.Severity_Debug, Content: "untraced", SessionID: 2})
+
+	logger.SetErrorLogLevel(clog.Severity_Debug)
+	clog.Record(&clog.GeneralMessage{Severity: clog.Severity_Debug, Content: "debug", SessionID: 2})
+
+	if r := cmp.Diff(loggedValue, []string{"[Debug] traced", "[Debug] debug"}); r != "" {
+		t.Error(r)
+	}
+
+	common.Must(logger.Close())
+}
@@ -0,0 +1,111 @@
+package log
+
+import (
+	gonet "net"
+	"time"
+
+	"github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/common/net"
+)
+
+// TraceFilter matches the sessions to trace. Empty fields match all sessions.
+type TraceFilter struct {
+	Email      string
+	InboundTag string
+	Source     *gonet.IPNet
+}
+
+func (f *TraceFilter) match(msg *log.AccessMessage) bool {
+	if len(f.Email) > 0 && f.Email != msg.Email {
+		return false
+	}
+	if len(f.InboundTag) > 0 && f.InboundTag != msg.InboundTag {
+		return false
+	}
+	if f.Source != nil {
+		ip := sourceIP(msg.From)
+		if ip == nil || !f.Source.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// sourceIP returns the IP of the source of an access message.
+func sourceIP(from interface{}) net.IP {
+	switch from := from.(type) {
+	case net.Destination:
+		if from.Address.Family().IsIP() {
+			return from.Address.IP()
+		}
+	case *gonet.TCPAddr:
+		return from.IP
+	case *gonet.UDPAddr:
+		return from.IP
+	case gonet.Addr:
+		if host, _, err := gonet.SplitHostPort(from.String()); err == nil {
+			return gonet.ParseIP(host)
+		}
+	}
+	return nil
+}
+
+type trace struct {
+	filter TraceFilter
+	expire time.Time
+}
+
+// Trace enables debug log of the sessions matching filter until expire, regardless of the error log level.
+// Sessions are matched when they are dispatched, so that only the logs afterwards are traced.
+func (g *Instance) Trace(filter TraceFilter, expire time.Time) {
+	g.traceAccess.Lock()
+	defer g.traceAccess.Unlock()
+
+	g.traces = append(g.traces, &trace{
+		filter: filter,
+		expire: expire,
+	})
+}
+
+// traceSession starts tracing the session of msg, if it matches any trace.
+func (g *Instance) traceSession(msg *log.AccessMessage) {
+	if msg.SessionID == 0 {
+		return
+	}
+
+	g.traceAccess.Lock()
+	defer g.traceAccess.Unlock()
+
+	now := time.Now()
+	traces := g.traces[:0]
+	for _, t := range g.traces {
+		if now.Before(t.expire) {
+			traces = append(traces, t)
+		}
+	}
+	g.traces = traces
+	for id, expire := range g.tracedSessions {
+		if !now.Before(expire) {
+			delete(g.tracedSessions, id)
+		}
+	}
+
+	for _, t := range g.traces {
+		if t.filter.match(msg) && t.expire.After(g.tracedSessions[msg.SessionID]) {
+			g.tracedSessions[msg.SessionID] = t.expire
+		}
+	}
+}
+
+// isTraced returns whether the session of the given ID is being traced.
+func (g *Instance) isTraced(sessionID uint32) bool {
+	if sessionID == 0 {
+		return false
+	}
+
+	g.traceAccess.RLock()
+	defer g.traceAccess.RUnlock()
+
+	expire, found := g.tracedSessions[sessionID]
+	return found && time.Now().Before(expire)
+}
@@ -6,9 +6,12 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/xtls/xray-core/app/observatory"
 	"github.com/xtls/xray-core/app/stats"
+	"github.com/xtls/xray-core/app/stats/command"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/signal/done"
@@ -18,67 +21,122 @@ import (
 	feature_stats "github.com/xtls/xray-core/features/stats"
 )
 
+var (
+	publishOnce   sync.Once
+	latestHandler atomic.Pointer[MetricsHandler]
+)
+
 type MetricsHandler struct {
+	ctx          context.Context
 	ohm          outbound.Manager
 	statsManager feature_stats.Manager
 	observatory  extension.Observatory
+	statsServer  command.StatsServiceServer
 	tag          string
+	// mux serves the endpoints of this handler, and those registered on http.DefaultServeMux such
+	// as expvar and pprof.
+	mux *http.ServeMux
 }
 
 // NewMetricsHandler creates a new MetricsHandler based on the given config.
 func NewMetricsHandler(ctx context.Context, config *Config) (*MetricsHandler, error) {
 	c := &MetricsHandler{
+		ctx: ctx,
 		tag: config.Tag,
+		mux: http.NewServeMux(),
 	}
+	c.mux.Handle("/", http.DefaultServeMux)
 	common.Must(core.RequireFeatures(ctx, func(om outbound.Manager, sm feature_stats.Manager) {
 		c.statsManager = sm
+		c.statsServer = command.NewStatsServer(sm)
 		c.ohm = om
 	}))
-	expvar.Publish("stats", expvar.Func(func() interface{} {
-		manager, ok := c.statsManager.(*stats.Manager)
-		if !ok {
-			return nil
-		}
-		resp := map[string]map[string]map[string]int64{
-			"inbound":  {},
-			"outbound": {},
-			"user":     {},
+	// Variables of expvar are global, so they are published once and served by the latest handler.
+	latestHandler.Store(c)
+	publishOnce.Do(func() {
+		expvar.Publish("stats", expvar.Func(func() interface{} {
+			return latestHandler.Load().statsVars()
+		}))
+		expvar.Publish("observatory", expvar.Func(func() interface{} {
+			return latestHandler.Load().observatoryVars()
+		}))
+	})
+	if config.OpenMetrics {
+		path := config.OpenMetricsPath
+		if path == "" {
+			path = "/metrics"
 		}
-		manager.VisitCounters(func(name string, counter feature_stats.Counter) bool {
-			nameSplit := strings.Split(name, ">>>")
-			typeName, tagOrUser, direction := nameSplit[0], nameSplit[1], nameSplit[3]
-			if item, found := resp[typeName][tagOrUser]; found {
-				item[direction] = counter.Value()
-			} else {
-				resp[typeName][tagOrUser] = map[string]int64{
-					direction: counter.Value(),
-				}
-			}
+		c.mux.HandleFunc(path, c.ServeOpenMetrics)
+	}
+	return c, nil
+}
+
+// statsVars returns the traffic counters for expvar.
+func (p *MetricsHandler) statsVars() interface{} {
+	manager, ok := p.statsManager.(*stats.Manager)
+	if !ok {
+		return nil
+	}
+	resp := map[string]map[string]map[string]int64{
+		"inbound":  {},
+		"outbound": {},
+		"user":     {},
+	}
+	manager.VisitCounters(func(name string, counter feature_stats.Counter) bool {
+		nameSplit := strings.Split(name, ">>>")
+		if len(nameSplit) != 4 {
 			return true
-		})
-		return resp
-	}))
-	expvar.Publish("observatory", expvar.Func(func() interface{} {
-		if c.observatory == nil {
-			common.Must(core.RequireFeatures(ctx, func(observatory extension.Observatory) error {
-				c.observatory = observatory
-				return nil
-			}))
-			if c.observatory == nil {
-				return nil
-			}
 		}
-		resp := map[string]*observatory.OutboundStatus{}
-		if o, err := c.observatory.GetObservation(context.Background()); err != nil {
-			return err
+		typeName, tagOrUser, direction := nameSplit[0], nameSplit[1], nameSplit[3]
+		if _, found := resp[typeName]; !found {
+			// Such as hit counters of routing rules.
+			resp[typeName] = map[string]map[string]int64{}
+		}
+		if item, found := resp[typeName][tagOrUser]; found {
+			item[direction] = counter.Value()
 		} else {
-			for _, x := range o.(*observatory.ObservationResult).GetStatus() {
-				resp[x.OutboundTag] = x
+			resp[typeName][tagOrUser] = map[string]int64{
+				direction: counter.Value(),
 			}
 		}
-		return resp
-	}))
-	return c, nil
+		return true
+	})
+	return resp
+}
+
+// observatoryVars returns the outbound status for expvar.
+func (p *MetricsHandler) observatoryVars() interface{} {
+	status, err := p.observationStatus()
+	if err != nil {
+		return err
+	}
+	if p.observatory == nil {
+		return nil
+	}
+	resp := map[string]*observatory.OutboundStatus{}
+	for _, x := range status {
+		resp[x.OutboundTag] = x
+	}
+	return resp
+}
+
+// observationStatus returns the outbound status reported by the observatory,
+// or nil if no observatory is configured.
+func (p *MetricsHandler) observationStatus() ([]*observatory.OutboundStatus, error) {
+	if p.observatory == nil {
+		common.Must(core.RequireFeatures(p.ctx, func(observatory extension.Observatory) error {
+			p.observatory = observatory
+			return nil
+		}))
+		if p.observatory == nil {
+			return nil, nil
+		}
+	}
+	o, err := p.observatory.GetObservation(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return o.(*observatory.ObservationResult).GetStatus(), nil
 }
 
 func (p *MetricsHandler) Type() interface{} {
@@ -92,7 +150,7 @@ func (p *MetricsHandler) Start() error {
 	}
 
 	go func() {
-		if err := http.Serve(listener, http.DefaultServeMux); err != nil {
+		if err := http.Serve(listener, p.mux); err != nil {
 			newError("failed to start metrics server").Base(err).AtError().WriteToLog()
 		}
 	}()
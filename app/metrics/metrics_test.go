@@ -0,0 +1,33 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/xtls/xray-core/app/dispatcher"
+	. "github.com/xtls/xray-core/app/metrics"
+	"github.com/xtls/xray-core/app/proxyman"
+	_ "github.com/xtls/xray-core/app/proxyman/inbound"
+	_ "github.com/xtls/xray-core/app/proxyman/outbound"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/core"
+)
+
+func TestMultipleInstances(t *testing.T) {
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&Config{Tag: "metrics_out", OpenMetrics: true}),
+		},
+	}
+
+	// Endpoints of each instance are registered separately, so that instances can be created
+	// again in the same process.
+	for i := 0; i < 2; i++ {
+		server, err := core.New(config)
+		common.Must(err)
+		common.Must(server.Close())
+	}
+}
@@ -0,0 +1,217 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/app/stats/command"
+	feature_stats "github.com/xtls/xray-core/features/stats"
+)
+
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// trafficSample is a single traffic counter parsed from a stats.Manager counter name
+// such as "inbound>>>tag>>>traffic>>>uplink".
+type trafficSample struct {
+	typeName  string
+	name      string
+	direction string
+	value     int64
+}
+
+// counterVisitor is implemented by stats managers that can enumerate their counters.
+type counterVisitor interface {
+	VisitCounters(func(string, feature_stats.Counter) bool)
+}
+
+func collectTraffic(sm feature_stats.Manager) []trafficSample {
+	visitor, ok := sm.(counterVisitor)
+	if !ok {
+		return nil
+	}
+	var samples []trafficSample
+	visitor.VisitCounters(func(name string, counter feature_stats.Counter) bool {
+		nameSplit := strings.Split(name, ">>>")
+		if len(nameSplit) != 4 || nameSplit[2] != "traffic" {
+			return true
+		}
+		samples = append(samples, trafficSample{
+			typeName:  nameSplit[0],
+			name:      nameSplit[1],
+			direction: nameSplit[3],
+			value:     counter.Value(),
+		})
+		return true
+	})
+	sort.Slice(samples, func(i, j int) bool {
+		a, b := samples[i], samples[j]
+		if a.typeName != b.typeName {
+			return a.typeName < b.typeName
+		}
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		return a.direction < b.direction
+	})
+	return samples
+}
+
+// routeHits is the hit counters of routing rules parsed from stats.Manager counter names such as
+// "rule>>>tag>>>route>>>hits", and the counter of routes matching no rules.
+type routeHits struct {
+	rules     map[string]int64
+	unmatched int64
+	found     bool
+}
+
+func collectRouteHits(sm feature_stats.Manager) routeHits {
+	hits := routeHits{rules: map[string]int64{}}
+	visitor, ok := sm.(counterVisitor)
+	if !ok {
+		return hits
+	}
+	visitor.VisitCounters(func(name string, counter feature_stats.Counter) bool {
+		nameSplit := strings.Split(name, ">>>")
+		if len(nameSplit) != 4 || nameSplit[2] != "route" || nameSplit[3] != "hits" {
+			return true
+		}
+		switch {
+		case nameSplit[0] == "rule":
+			hits.rules[nameSplit[1]] = counter.Value()
+		case nameSplit[0] == "router" && nameSplit[1] == "unmatched":
+			hits.unmatched = counter.Value()
+			hits.found = true
+		}
+		return true
+	})
+	return hits
+}
+
+// escapeLabelValue escapes a label value as required by the OpenMetrics text format.
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func writeFamily(w io.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+}
+
+func writeTraffic(w io.Writer, samples []trafficSample) {
+	if len(samples) == 0 {
+		return
+	}
+	writeFamily(w, "xray_traffic_bytes", "counter", "Traffic in bytes recorded by the stats manager.")
+	for _, s := range samples {
+		nameLabel := "tag"
+		if s.typeName == "user" {
+			nameLabel = "user"
+		}
+		fmt.Fprintf(w, "xray_traffic_bytes_total{type=\"%s\",%s=\"%s\",direction=\"%s\"} %d\n",
+			escapeLabelValue(s.typeName), nameLabel, escapeLabelValue(s.name), escapeLabelValue(s.direction), s.value)
+	}
+}
+
+func writeRouteHits(w io.Writer, hits routeHits) {
+	if len(hits.rules) > 0 {
+		rules := make([]string, 0, len(hits.rules))
+		for rule := range hits.rules {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+		writeFamily(w, "xray_routing_rule_hits", "counter", "Routes matching the tagged routing rules.")
+		for _, rule := range rules {
+			fmt.Fprintf(w, "xray_routing_rule_hits_total{rule=\"%s\"} %d\n", escapeLabelValue(rule), hits.rules[rule])
+		}
+	}
+	if hits.found {
+		writeFamily(w, "xray_routing_unmatched", "counter", "Routes matching no routing rules.")
+		fmt.Fprintf(w, "xray_routing_unmatched_total %d\n", hits.unmatched)
+	}
+}
+
+func writeObservatory(w io.Writer, status []*observatory.OutboundStatus) {
+	if len(status) == 0 {
+		return
+	}
+	sorted := make([]*observatory.OutboundStatus, len(status))
+	copy(sorted, status)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].OutboundTag < sorted[j].OutboundTag
+	})
+
+	writeFamily(w, "xray_observatory_alive", "gauge", "Whether the outbound passed its last probe.")
+	for _, s := range sorted {
+		alive := 0
+		if s.Alive {
+			alive = 1
+		}
+		fmt.Fprintf(w, "xray_observatory_alive{outbound=\"%s\"} %d\n", escapeLabelValue(s.OutboundTag), alive)
+	}
+	writeFamily(w, "xray_observatory_delay_seconds", "gauge", "Duration of the last probe request.")
+	for _, s := range sorted {
+		fmt.Fprintf(w, "xray_observatory_delay_seconds{outbound=\"%s\"} %s\n", escapeLabelValue(s.OutboundTag), strconv.FormatFloat(float64(s.Delay)/1000, 'f', -1, 64))
+	}
+	writeFamily(w, "xray_observatory_last_seen_timestamp_seconds", "gauge", "Last time the outbound was known to be alive.")
+	for _, s := range sorted {
+		fmt.Fprintf(w, "xray_observatory_last_seen_timestamp_seconds{outbound=\"%s\"} %d\n", escapeLabelValue(s.OutboundTag), s.LastSeenTime)
+	}
+	writeFamily(w, "xray_observatory_last_try_timestamp_seconds", "gauge", "Last time the outbound was probed.")
+	for _, s := range sorted {
+		fmt.Fprintf(w, "xray_observatory_last_try_timestamp_seconds{outbound=\"%s\"} %d\n", escapeLabelValue(s.OutboundTag), s.LastTryTime)
+	}
+}
+
+func writeSys(w io.Writer, sys *command.SysStatsResponse) {
+	metrics := []struct {
+		name  string
+		typ   string
+		help  string
+		value float64
+	}{
+		{"xray_uptime_seconds", "gauge", "Time since the metrics handler was started.", float64(sys.Uptime)},
+		{"xray_goroutines", "gauge", "Number of goroutines that currently exist.", float64(sys.NumGoroutine)},
+		{"xray_memstats_alloc_bytes", "gauge", "Bytes of allocated heap objects.", float64(sys.Alloc)},
+		{"xray_memstats_sys_bytes", "gauge", "Bytes of memory obtained from the OS.", float64(sys.Sys)},
+		{"xray_memstats_live_objects", "gauge", "Number of live heap objects.", float64(sys.LiveObjects)},
+		{"xray_memstats_total_alloc_bytes", "counter", "Cumulative bytes allocated for heap objects.", float64(sys.TotalAlloc)},
+		{"xray_memstats_mallocs", "counter", "Cumulative count of heap objects allocated.", float64(sys.Mallocs)},
+		{"xray_memstats_frees", "counter", "Cumulative count of heap objects freed.", float64(sys.Frees)},
+		{"xray_gc", "counter", "Number of completed GC cycles.", float64(sys.NumGC)},
+		{"xray_gc_pause_seconds", "counter", "Cumulative time spent in GC stop-the-world pauses.", float64(sys.PauseTotalNs) / float64(time.Second)},
+	}
+	for _, m := range metrics {
+		writeFamily(w, m.name, m.typ, m.help)
+		sample := m.name
+		if m.typ == "counter" {
+			sample += "_total"
+		}
+		fmt.Fprintf(w, "%s %s\n", sample, strconv.FormatFloat(m.value, 'f', -1, 64))
+	}
+}
+
+// ServeOpenMetrics writes all available metrics in OpenMetrics text format.
+func (p *MetricsHandler) ServeOpenMetrics(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	writeTraffic(&buf, collectTraffic(p.statsManager))
+	writeRouteHits(&buf, collectRouteHits(p.statsManager))
+	if status, err := p.observationStatus(); err == nil {
+		writeObservatory(&buf, status)
+	}
+	if p.statsServer != nil {
+		if sys, err := p.statsServer.GetSysStats(r.Context(), &command.SysStatsRequest{}); err == nil {
+			writeSys(&buf, sys)
+		}
+	}
+	buf.WriteString("# EOF\n")
+
+	w.Header().Set("Content-Type", openMetricsContentType)
+	w.Write(buf.Bytes())
+}
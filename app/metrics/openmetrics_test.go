@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/app/stats"
+	"github.com/xtls/xray-core/common"
+)
+
+func TestOpenMetricsTraffic(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	for name, value := range map[string]int64{
+		"inbound>>>api>>>traffic>>>uplink":           1,
+		"inbound>>>api>>>traffic>>>downlink":         2,
+		"user>>>a\"b@example.com>>>traffic>>>uplink": 3,
+		"outbound>>>direct>>>traffic>>>downlink":     4,
+		"invalid":                                    5,
+	} {
+		c, err := m.RegisterCounter(name)
+		common.Must(err)
+		c.Set(value)
+	}
+
+	var buf bytes.Buffer
+	writeTraffic(&buf, collectTraffic(m))
+
+	expected := `# TYPE xray_traffic_bytes counter
+# HELP xray_traffic_bytes Traffic in bytes recorded by the stats manager.
+xray_traffic_bytes_total{type="inbound",tag="api",direction="downlink"} 2
+xray_traffic_bytes_total{type="inbound",tag="api",direction="uplink"} 1
+xray_traffic_bytes_total{type="outbound",tag="direct",direction="downlink"} 4
+xray_traffic_bytes_total{type="user",user="a\"b@example.com",direction="uplink"} 3
+`
+	if r := cmp.Diff(buf.String(), expected); r != "" {
+		t.Error(r)
+	}
+}
+
+func TestOpenMetricsRouteHits(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	for name, value := range map[string]int64{
+		"rule>>>direct-cn>>>route>>>hits":   3,
+		"rule>>>block-ads>>>route>>>hits":   0,
+		"router>>>unmatched>>>route>>>hits": 7,
+		"inbound>>>api>>>traffic>>>uplink":  1,
+	} {
+		c, err := m.RegisterCounter(name)
+		common.Must(err)
+		c.Set(value)
+	}
+
+	var buf bytes.Buffer
+	writeRouteHits(&buf, collectRouteHits(m))
+
+	expected := `# TYPE xray_routing_rule_hits counter
+# HELP xray_routing_rule_hits Routes matching the tagged routing rules.
+xray_routing_rule_hits_total{rule="block-ads"} 0
+xray_routing_rule_hits_total{rule="direct-cn"} 3
+# TYPE xray_routing_unmatched counter
+# HELP xray_routing_unmatched Routes matching no routing rules.
+xray_routing_unmatched_total 7
+`
+	if r := cmp.Diff(buf.String(), expected); r != "" {
+		t.Error(r)
+	}
+}
+
+func TestOpenMetricsObservatory(t *testing.T) {
+	var buf bytes.Buffer
+	writeObservatory(&buf, []*observatory.OutboundStatus{
+		{OutboundTag: "b", Alive: false, Delay: 99999999, LastTryTime: 20},
+		{OutboundTag: "a", Alive: true, Delay: 150, LastSeenTime: 10, LastTryTime: 10},
+	})
+
+	expected := `# TYPE xray_observatory_alive gauge
+# HELP xray_observatory_alive Whether the outbound passed its last probe.
+xray_observatory_alive{outbound="a"} 1
+xray_observatory_alive{outbound="b"} 0
+# TYPE xray_observatory_delay_seconds gauge
+# HELP xray_observatory_delay_seconds Duration of the last probe request.
+xray_observatory_delay_seconds{outbound="a"} 0.15
+xray_observatory_delay_seconds{outbound="b"} 99999.999
+# TYPE xray_observatory_last_seen_timestamp_seconds gauge
+# HELP xray_observatory_last_seen_timestamp_seconds Last time the outbound was known to be alive.
+xray_observatory_last_seen_timestamp_seconds{outbound="a"} 10
+xray_observatory_last_seen_timestamp_seconds{outbound="b"} 0
+# TYPE xray_observatory_last_try_timestamp_seconds gauge
+# HELP xray_observatory_last_try_timestamp_seconds Last time the outbound was probed.
+xray_observatory_last_try_timestamp_seconds{outbound="a"} 10
+xray_observatory_last_try_timestamp_seconds{outbound="b"} 20
+`
+	if r := cmp.Diff(buf.String(), expected); r != "" {
+		t.Error(r)
+	}
+}
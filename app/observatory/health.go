@@ -0,0 +1,76 @@
+package observatory
+
+import (
+	"math"
+	"time"
+)
+
+// healthHistory is a sliding window of the recent probe results of an outbound.
+type healthHistory struct {
+	rtts []time.Duration // a negative value marks a failed probe
+	next int
+	full bool
+}
+
+func newHealthHistory(size int) *healthHistory {
+	return &healthHistory{rtts: make([]time.Duration, size)}
+}
+
+// Put records the RTT of a succeeded probe.
+func (h *healthHistory) Put(rtt time.Duration) {
+	h.rtts[h.next] = rtt
+	h.next++
+	if h.next == len(h.rtts) {
+		h.next = 0
+		h.full = true
+	}
+}
+
+// PutFail records a failed probe.
+func (h *healthHistory) PutFail() {
+	h.Put(-1)
+}
+
+// Get returns the statistics of the probes in the window.
+func (h *healthHistory) Get() *HealthPingMeasurementResult {
+	rtts := h.rtts[:h.next]
+	if h.full {
+		rtts = h.rtts
+	}
+
+	result := &HealthPingMeasurementResult{All: int64(len(rtts))}
+	var sum, min, max time.Duration
+	for _, rtt := range rtts {
+		if rtt < 0 {
+			result.Fail++
+			continue
+		}
+		sum += rtt
+		if min == 0 || rtt < min {
+			min = rtt
+		}
+		if rtt > max {
+			max = rtt
+		}
+	}
+	succeeded := result.All - result.Fail
+	if succeeded == 0 {
+		return result
+	}
+
+	average := sum / time.Duration(succeeded)
+	var variance float64
+	for _, rtt := range rtts {
+		if rtt < 0 {
+			continue
+		}
+		variance += math.Pow(float64(rtt-average), 2)
+	}
+	deviation := time.Duration(math.Sqrt(variance / float64(succeeded)))
+
+	result.Average = average.Milliseconds()
+	result.Deviation = deviation.Milliseconds()
+	result.Min = min.Milliseconds()
+	result.Max = max.Milliseconds()
+	return result
+}
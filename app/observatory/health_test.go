@@ -0,0 +1,42 @@
+package observatory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestHealthHistory(t *testing.T) {
+	h := newHealthHistory(4)
+	if r := h.Get(); !proto.Equal(r, &HealthPingMeasurementResult{}) {
+		t.Error("unexpected result of empty history: ", r)
+	}
+
+	h.PutFail()
+	h.Put(100 * time.Millisecond)
+	h.Put(300 * time.Millisecond)
+	if r := h.Get(); !proto.Equal(r, &HealthPingMeasurementResult{
+		All:       3,
+		Fail:      1,
+		Average:   200,
+		Deviation: 100,
+		Min:       100,
+		Max:       300,
+	}) {
+		t.Error("unexpected result: ", r)
+	}
+
+	// The failed probe drops out of the window.
+	h.Put(200 * time.Millisecond)
+	h.Put(200 * time.Millisecond)
+	if r := h.Get(); !proto.Equal(r, &HealthPingMeasurementResult{
+		All:       4,
+		Average:   200,
+		Deviation: 70,
+		Min:       100,
+		Max:       300,
+	}) {
+		t.Error("unexpected result: ", r)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,12 +22,20 @@ import (
 	"github.com/xtls/xray-core/transport/internet/tagged"
 )
 
+const (
+	// healthHistorySize is the number of recent probes kept for each outbound.
+	healthHistorySize = 10
+	// defaultBurstSamplingCount is the number of probes per round in burst mode.
+	defaultBurstSamplingCount = 3
+)
+
 type Observer struct {
 	config *Config
 	ctx    context.Context
 
 	statusLock sync.Mutex
 	status     []*OutboundStatus
+	history    map[string]*healthHistory
 
 	finished *done.Instance
 
@@ -34,7 +43,14 @@ type Observer struct {
 }
 
 func (o *Observer) GetObservation(ctx context.Context) (proto.Message, error) {
-	return &ObservationResult{Status: o.status}, nil
+	o.statusLock.Lock()
+	defer o.statusLock.Unlock()
+
+	status := make([]*OutboundStatus, 0, len(o.status))
+	for _, s := range o.status {
+		status = append(status, proto.Clone(s).(*OutboundStatus))
+	}
+	return &ObservationResult{Status: status}, nil
 }
 
 func (o *Observer) Type() interface{} {
@@ -76,8 +92,7 @@ func (o *Observer) background() {
 		if !o.config.EnableConcurrency {
 			sort.Strings(outbounds)
 			for _, v := range outbounds {
-				result := o.probe(v)
-				o.updateStatusForResult(v, &result)
+				o.updateStatusForResults(v, o.probeRound(v))
 				if o.finished.Done() {
 					return
 				}
@@ -90,8 +105,7 @@ func (o *Observer) background() {
 
 		for _, v := range outbounds {
 			go func(v string) {
-				result := o.probe(v)
-				o.updateStatusForResult(v, &result)
+				o.updateStatusForResults(v, o.probeRound(v))
 				ch <- struct{}{}
 			}(v)
 		}
@@ -114,6 +128,49 @@ func (o *Observer) updateStatus(outbounds []string) {
 	_ = outbounds
 }
 
+// probeRound probes the outbound once, or several times in burst mode.
+func (o *Observer) probeRound(outbound string) []ProbeResult {
+	count := 1
+	if burst := o.config.Burst; burst != nil {
+		count = defaultBurstSamplingCount
+		if burst.SamplingCount > 0 {
+			count = int(burst.SamplingCount)
+		}
+	}
+	results := make([]ProbeResult, 0, count)
+	for i := 0; i < count && !o.finished.Done(); i++ {
+		results = append(results, o.probe(outbound))
+	}
+	return results
+}
+
+// historySize returns the number of recent probe results to keep for each outbound.
+func (o *Observer) historySize() int {
+	burst := o.config.Burst
+	if burst == nil {
+		return healthHistorySize
+	}
+	if burst.WindowSize > 0 {
+		return int(burst.WindowSize)
+	}
+	if burst.SamplingCount > 0 {
+		return int(burst.SamplingCount) * 10
+	}
+	return defaultBurstSamplingCount * 10
+}
+
+func (o *Observer) isExpectedStatus(code int) bool {
+	if len(o.config.ExpectedStatus) == 0 {
+		return true
+	}
+	for _, expected := range o.config.ExpectedStatus {
+		if int(expected) == code {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *Observer) probe(outbound string) ProbeResult {
 	errorCollectorForRequest := newErrorCollector()
 
@@ -159,13 +216,24 @@ func (o *Observer) probe(outbound string) ProbeResult {
 		if o.config.ProbeUrl != "" {
 			probeURL = o.config.ProbeUrl
 		}
-		response, err := httpClient.Get(probeURL)
+		method := http.MethodGet
+		if strings.EqualFold(o.config.ProbeMethod, http.MethodHead) {
+			method = http.MethodHead
+		}
+		request, err := http.NewRequest(method, probeURL, nil)
+		if err != nil {
+			return newError("invalid probe request").Base(err)
+		}
+		response, err := httpClient.Do(request)
 		if err != nil {
 			return newError("outbound failed to relay connection").Base(err)
 		}
 		if response.Body != nil {
 			response.Body.Close()
 		}
+		if !o.isExpectedStatus(response.StatusCode) {
+			return newError("unexpected status ", response.Status)
+		}
 		endTime := time.Now()
 		GETTime = endTime.Sub(startTime)
 		return nil
@@ -173,7 +241,7 @@ func (o *Observer) probe(outbound string) ProbeResult {
 	if err != nil {
 		fullerr := newError("underlying connection failed").Base(errorCollectorForRequest.UnderlyingError())
 		fullerr = newError("with outbound handler report").Base(fullerr)
-		fullerr = newError("GET request failed:", err).Base(fullerr)
+		fullerr = newError("probe request failed:", err).Base(fullerr)
 		fullerr = newError("the outbound ", outbound, " is dead:").Base(fullerr)
 		fullerr = fullerr.AtInfo()
 		fullerr.WriteToLog()
@@ -183,7 +251,11 @@ func (o *Observer) probe(outbound string) ProbeResult {
 	return ProbeResult{Alive: true, Delay: GETTime.Milliseconds()}
 }
 
-func (o *Observer) updateStatusForResult(outbound string, result *ProbeResult) {
+func (o *Observer) updateStatusForResults(outbound string, results []ProbeResult) {
+	if len(results) == 0 {
+		return
+	}
+
 	o.statusLock.Lock()
 	defer o.statusLock.Unlock()
 	var status *OutboundStatus
@@ -194,15 +266,38 @@ func (o *Observer) updateStatusForResult(outbound string, result *ProbeResult) {
 		o.status = append(o.status, status)
 	}
 
+	history, found := o.history[outbound]
+	if !found {
+		history = newHealthHistory(o.historySize())
+		o.history[outbound] = history
+	}
+
+	var lastAlive, lastFailed *ProbeResult
+	for i := range results {
+		result := &results[i]
+		if result.Alive {
+			history.Put(time.Duration(result.Delay) * time.Millisecond)
+			lastAlive = result
+		} else {
+			history.PutFail()
+			lastFailed = result
+		}
+	}
+	health := history.Get()
+
 	status.LastTryTime = time.Now().Unix()
 	status.OutboundTag = outbound
-	status.Alive = result.Alive
-	if result.Alive {
-		status.Delay = result.Delay
+	status.Alive = lastAlive != nil
+	status.HealthPing = health
+	if status.Alive {
+		status.Delay = lastAlive.Delay
+		if o.config.Burst != nil {
+			status.Delay = health.Average
+		}
 		status.LastSeenTime = status.LastTryTime
 		status.LastErrorReason = ""
 	} else {
-		status.LastErrorReason = result.LastErrorReason
+		status.LastErrorReason = lastFailed.LastErrorReason
 		status.Delay = 99999999
 	}
 }
@@ -225,9 +320,10 @@ func New(ctx context.Context, config *Config) (*Observer, error) {
 		return nil, newError("Cannot get depended features").Base(err)
 	}
 	return &Observer{
-		config: config,
-		ctx:    ctx,
-		ohm:    outboundManager,
+		config:  config,
+		ctx:     ctx,
+		ohm:     outboundManager,
+		history: make(map[string]*healthHistory),
 	}, nil
 }
 
@@ -0,0 +1,181 @@
+package policy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/features/policy"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// limiterIdleTimeout is the time after which limiters of a user are removed, if they are
+	// neither requested nor consuming tokens, and no session is using them.
+	limiterIdleTimeout = 10 * time.Minute
+	// limiterPruneInterval is the interval to remove idle limiters of users.
+	limiterPruneInterval = time.Minute
+)
+
+// limiterPair holds the token buckets of a user or an inbound.
+type limiterPair struct {
+	level    uint32
+	uplink   *rate.Limiter
+	downlink *rate.Limiter
+	// lastUsed is the last time the limiters are requested or released, only used for users.
+	lastUsed time.Time
+	// sessions is the number of sessions using the limiters, only used for users.
+	sessions int
+}
+
+func limitOf(bytesPerSecond int64) (rate.Limit, int) {
+	if bytesPerSecond <= 0 {
+		return rate.Inf, 0
+	}
+	// Allow bursts of one second worth of traffic.
+	return rate.Limit(bytesPerSecond), int(bytesPerSecond)
+}
+
+func newLimiterPair(level uint32, b policy.Bandwidth) *limiterPair {
+	return &limiterPair{
+		level:    level,
+		uplink:   rate.NewLimiter(limitOf(b.Uplink)),
+		downlink: rate.NewLimiter(limitOf(b.Downlink)),
+	}
+}
+
+func (p *limiterPair) set(b policy.Bandwidth) {
+	setLimit(p.uplink, b.Uplink)
+	setLimit(p.downlink, b.Downlink)
+}
+
+func setLimit(l *rate.Limiter, bytesPerSecond int64) {
+	limit, burst := limitOf(bytesPerSecond)
+	l.SetBurst(burst)
+	l.SetLimit(limit)
+}
+
+// idle returns whether the limiters are full of tokens, which means no traffic is limited by them
+// recently.
+func (p *limiterPair) idle(now time.Time) bool {
+	return isFull(p.uplink, now) && isFull(p.downlink, now)
+}
+
+func isFull(l *rate.Limiter, now time.Time) bool {
+	return l.Limit() == rate.Inf || l.TokensAt(now) >= float64(l.Burst())
+}
+
+func isLimited(b policy.Bandwidth) bool {
+	return b.Uplink > 0 || b.Downlink > 0
+}
+
+// LimitersForUser implements policy.BandwidthManager.
+// Users without email get limiters of their own for each connection.
+func (m *Instance) LimitersForUser(level uint32, email string) (*rate.Limiter, *rate.Limiter, func()) {
+	b := m.ForLevel(level).Bandwidth
+	if len(email) == 0 {
+		if !isLimited(b) {
+			return nil, nil, func() {}
+		}
+		p := newLimiterPair(level, b)
+		return p.uplink, p.downlink, func() {}
+	}
+
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	p, found := m.userLimiters[email]
+	if !found {
+		if !isLimited(b) {
+			return nil, nil, func() {}
+		}
+		p = newLimiterPair(level, b)
+		m.userLimiters[email] = p
+	} else if p.level != level {
+		p.level = level
+		p.set(b)
+	}
+	p.lastUsed = time.Now()
+	p.sessions++
+
+	var once sync.Once
+	return p.uplink, p.downlink, func() {
+		once.Do(func() {
+			m.access.Lock()
+			defer m.access.Unlock()
+
+			p.sessions--
+			p.lastUsed = time.Now()
+		})
+	}
+}
+
+// pruneLimiters removes the limiters of users that are idle for limiterIdleTimeout, so that
+// limiters of users no longer connecting are not kept forever. Limiters used by any session are
+// kept, even if the sessions are quiet, so that new sessions share the limits with them.
+func (m *Instance) pruneLimiters(now time.Time) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	for email, p := range m.userLimiters {
+		if p.sessions == 0 && now.Sub(p.lastUsed) >= limiterIdleTimeout && p.idle(now) {
+			delete(m.userLimiters, email)
+		}
+	}
+}
+
+// LimitersForInbound implements policy.BandwidthManager.
+func (m *Instance) LimitersForInbound(tag string) (*rate.Limiter, *rate.Limiter) {
+	if len(tag) == 0 {
+		return nil, nil
+	}
+
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	p, found := m.inboundLimiters[tag]
+	if !found {
+		b := m.inbounds[tag]
+		if !isLimited(b) {
+			return nil, nil
+		}
+		p = newLimiterPair(0, b)
+		m.inboundLimiters[tag] = p
+	}
+	return p.uplink, p.downlink
+}
+
+// SetLevelBandwidth implements policy.BandwidthManager.
+// The new limit applies at once to users that are already limited, and to new connections of other users.
+func (m *Instance) SetLevelBandwidth(level uint32, bandwidth policy.Bandwidth) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	p, found := m.levels[level]
+	if !found {
+		p = defaultPolicy()
+		m.levels[level] = p
+	}
+	p.Bandwidth = &Policy_Bandwidth{}
+	if bandwidth.Uplink > 0 {
+		p.Bandwidth.Uplink = uint64(bandwidth.Uplink)
+	}
+	if bandwidth.Downlink > 0 {
+		p.Bandwidth.Downlink = uint64(bandwidth.Downlink)
+	}
+	for _, l := range m.userLimiters {
+		if l.level == level {
+			l.set(bandwidth)
+		}
+	}
+}
+
+// SetInboundBandwidth implements policy.BandwidthManager.
+func (m *Instance) SetInboundBandwidth(tag string, bandwidth policy.Bandwidth) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	m.inbounds[tag] = bandwidth
+	if l, found := m.inboundLimiters[tag]; found {
+		l.set(bandwidth)
+	}
+}
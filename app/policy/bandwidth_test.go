@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+)
+
+func TestPruneLimiters(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			0: {
+				Bandwidth: &Policy_Bandwidth{
+					Uplink:   1024,
+					Downlink: 1024,
+				},
+			},
+		},
+	})
+	common.Must(err)
+
+	idle, _, release := manager.LimitersForUser(0, "idle@example.com")
+	release()
+	busy, _, release := manager.LimitersForUser(0, "busy@example.com")
+	release()
+	_, _, release = manager.LimitersForUser(0, "recent@example.com")
+	release()
+	// The session of the user is still open, but quiet.
+	manager.LimitersForUser(0, "quiet@example.com")
+
+	now := time.Now()
+	// Limiters still consuming tokens are kept, even if they are not requested for a while.
+	busy.AllowN(now, 1024)
+	manager.userLimiters["recent@example.com"].lastUsed = now
+	for _, email := range []string{"idle@example.com", "busy@example.com", "quiet@example.com"} {
+		manager.userLimiters[email].lastUsed = now.Add(-limiterIdleTimeout)
+	}
+	manager.pruneLimiters(now)
+
+	if _, found := manager.userLimiters["idle@example.com"]; found {
+		t.Error("expect idle limiters to be removed")
+	}
+	for _, email := range []string{"busy@example.com", "recent@example.com", "quiet@example.com"} {
+		if _, found := manager.userLimiters[email]; !found {
+			t.Error("expect limiters of ", email, " to be kept")
+		}
+	}
+	if u, _, _ := manager.LimitersForUser(0, "idle@example.com"); u == idle {
+		t.Error("expect new limiters after removed")
+	}
+}
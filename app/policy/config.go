@@ -58,6 +58,21 @@ func (p *Policy) overrideWith(another *Policy) {
 			Connection: another.Buffer.Connection,
 		}
 	}
+	if another.Connection != nil {
+		p.Connection = &Policy_Connection{
+			MaxSessions: another.Connection.MaxSessions,
+			MaxIps:      another.Connection.MaxIps,
+		}
+		if another.Connection.IpWindow != nil {
+			p.Connection.IpWindow = &Second{Value: another.Connection.IpWindow.Value}
+		}
+	}
+	if another.Bandwidth != nil {
+		p.Bandwidth = &Policy_Bandwidth{
+			Uplink:   another.Bandwidth.Uplink,
+			Downlink: another.Bandwidth.Downlink,
+		}
+	}
 }
 
 // ToCorePolicy converts this Policy to policy.Session.
@@ -77,9 +92,25 @@ func (p *Policy) ToCorePolicy() policy.Session {
 	if p.Buffer != nil {
 		cp.Buffer.PerConnection = p.Buffer.Connection
 	}
+	if p.Bandwidth != nil {
+		cp.Bandwidth = p.Bandwidth.ToCoreBandwidth()
+	}
+	if p.Connection != nil {
+		cp.Connection.MaxSessions = p.Connection.MaxSessions
+		cp.Connection.MaxIPs = p.Connection.MaxIps
+		cp.Connection.IPWindow = p.Connection.IpWindow.Duration()
+	}
 	return cp
 }
 
+// ToCoreBandwidth converts this Policy_Bandwidth to policy.Bandwidth.
+func (b *Policy_Bandwidth) ToCoreBandwidth() policy.Bandwidth {
+	return policy.Bandwidth{
+		Uplink:   int64(b.Uplink),
+		Downlink: int64(b.Downlink),
+	}
+}
+
 // ToCorePolicy converts this SystemPolicy to policy.System.
 func (p *SystemPolicy) ToCorePolicy() policy.System {
 	return policy.System{
@@ -0,0 +1,90 @@
+package policy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common/net"
+)
+
+// sourceIP tracks sessions of a user from one source IP.
+type sourceIP struct {
+	sessions uint32
+	lastSeen time.Time
+}
+
+// userConnections tracks concurrent sessions of a user.
+type userConnections struct {
+	sessions uint32
+	ips      map[string]*sourceIP
+}
+
+// prune removes source IPs that have no sessions and were last seen before the window.
+func (u *userConnections) prune(now time.Time, window time.Duration) {
+	for ip, s := range u.ips {
+		if s.sessions == 0 && now.Sub(s.lastSeen) >= window {
+			delete(u.ips, ip)
+		}
+	}
+}
+
+// AcquireSession implements policy.ConnectionLimiter.
+func (m *Instance) AcquireSession(level uint32, email string, source net.Address) (func(), error) {
+	limit := m.ForLevel(level).Connection
+	if len(email) == 0 || (limit.MaxSessions == 0 && limit.MaxIPs == 0) {
+		return func() {}, nil
+	}
+
+	m.connAccess.Lock()
+	defer m.connAccess.Unlock()
+
+	u, found := m.connections[email]
+	if !found {
+		u = &userConnections{
+			ips: make(map[string]*sourceIP),
+		}
+		m.connections[email] = u
+	}
+
+	if limit.MaxSessions > 0 && u.sessions >= limit.MaxSessions {
+		return nil, newError("user ", email, " exceeded the limit of ", limit.MaxSessions, " sessions")
+	}
+
+	now := time.Now()
+	u.prune(now, limit.IPWindow)
+
+	var ip string
+	if source != nil {
+		ip = source.String()
+	}
+	s, found := u.ips[ip]
+	if !found {
+		if limit.MaxIPs > 0 && uint32(len(u.ips)) >= limit.MaxIPs {
+			return nil, newError("user ", email, " exceeded the limit of ", limit.MaxIPs, " source IPs")
+		}
+		s = &sourceIP{}
+		u.ips[ip] = s
+	}
+
+	u.sessions++
+	s.sessions++
+	s.lastSeen = now
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.connAccess.Lock()
+			defer m.connAccess.Unlock()
+
+			u.sessions--
+			s.sessions--
+			s.lastSeen = time.Now()
+			if u.sessions == 0 {
+				u.prune(s.lastSeen, limit.IPWindow)
+				if len(u.ips) == 0 && m.connections[email] == u {
+					delete(m.connections, email)
+				}
+			}
+		})
+	}, nil
+}
@@ -2,22 +2,45 @@ package policy
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/task"
 	"github.com/xtls/xray-core/features/policy"
 )
 
 // Instance is an instance of Policy manager.
 type Instance struct {
-	levels map[uint32]*Policy
-	system *SystemPolicy
+	access          sync.RWMutex
+	levels          map[uint32]*Policy
+	system          *SystemPolicy
+	inbounds        map[string]policy.Bandwidth
+	userLimiters    map[string]*limiterPair
+	inboundLimiters map[string]*limiterPair
+
+	connAccess  sync.Mutex
+	connections map[string]*userConnections
+
+	limiterPrune *task.Periodic
 }
 
 // New creates new Policy manager instance.
 func New(ctx context.Context, config *Config) (*Instance, error) {
 	m := &Instance{
-		levels: make(map[uint32]*Policy),
-		system: config.System,
+		levels:          make(map[uint32]*Policy),
+		system:          config.System,
+		inbounds:        make(map[string]policy.Bandwidth),
+		userLimiters:    make(map[string]*limiterPair),
+		inboundLimiters: make(map[string]*limiterPair),
+		connections:     make(map[string]*userConnections),
+	}
+	m.limiterPrune = &task.Periodic{
+		Interval: limiterPruneInterval,
+		Execute: func() error {
+			m.pruneLimiters(time.Now())
+			return nil
+		},
 	}
 	if len(config.Level) > 0 {
 		for lv, p := range config.Level {
@@ -26,6 +49,9 @@ func New(ctx context.Context, config *Config) (*Instance, error) {
 			m.levels[lv] = pp
 		}
 	}
+	for tag, b := range config.InboundBandwidth {
+		m.inbounds[tag] = b.ToCoreBandwidth()
+	}
 
 	return m, nil
 }
@@ -37,6 +63,9 @@ func (*Instance) Type() interface{} {
 
 // ForLevel implements policy.Manager.
 func (m *Instance) ForLevel(level uint32) policy.Session {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
 	if p, ok := m.levels[level]; ok {
 		return p.ToCorePolicy()
 	}
@@ -53,12 +82,12 @@ func (m *Instance) ForSystem() policy.System {
 
 // Start implements common.Runnable.Start().
 func (m *Instance) Start() error {
-	return nil
+	return m.limiterPrune.Start()
 }
 
 // Close implements common.Closable.Close().
 func (m *Instance) Close() error {
-	return nil
+	return m.limiterPrune.Close()
 }
 
 func init() {
@@ -7,7 +7,9 @@ import (
 
 	. "github.com/xtls/xray-core/app/policy"
 	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/features/policy"
+	"golang.org/x/time/rate"
 )
 
 func TestPolicy(t *testing.T) {
@@ -43,3 +45,93 @@ func TestPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestBandwidth(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			0: {
+				Bandwidth: &Policy_Bandwidth{
+					Uplink:   1024,
+					Downlink: 2048,
+				},
+			},
+		},
+		InboundBandwidth: map[string]*Policy_Bandwidth{
+			"in": {
+				Downlink: 4096,
+			},
+		},
+	})
+	common.Must(err)
+
+	if b := manager.ForLevel(0).Bandwidth; b.Uplink != 1024 || b.Downlink != 2048 {
+		t.Error("unexpected bandwidth: ", b)
+	}
+
+	uplink, downlink, _ := manager.LimitersForUser(0, "test@example.com")
+	if uplink == nil || downlink == nil {
+		t.Fatal("expect limiters for user at level 0")
+	}
+	if uplink.Limit() != 1024 || downlink.Limit() != 2048 {
+		t.Error("unexpected limits: ", uplink.Limit(), " ", downlink.Limit())
+	}
+	if u, _, _ := manager.LimitersForUser(0, "test@example.com"); u != uplink {
+		t.Error("expect limiters shared by the same user")
+	}
+	if u, d, _ := manager.LimitersForUser(1, "other@example.com"); u != nil || d != nil {
+		t.Error("expect no limiters for level 1")
+	}
+
+	manager.SetLevelBandwidth(0, policy.Bandwidth{Uplink: 512})
+	if uplink.Limit() != 512 || downlink.Limit() != rate.Inf {
+		t.Error("unexpected limits after change: ", uplink.Limit(), " ", downlink.Limit())
+	}
+
+	if u, d := manager.LimitersForInbound("in"); u.Limit() != rate.Inf || d.Limit() != 4096 {
+		t.Error("unexpected inbound limits: ", u.Limit(), " ", d.Limit())
+	}
+	if u, d := manager.LimitersForInbound("other"); u != nil || d != nil {
+		t.Error("expect no limiters for inbound other")
+	}
+}
+
+func TestConnectionLimit(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			0: {
+				Connection: &Policy_Connection{
+					MaxSessions: 2,
+					MaxIps:      1,
+				},
+			},
+		},
+	})
+	common.Must(err)
+
+	ip1 := net.ParseAddress("10.0.0.1")
+	ip2 := net.ParseAddress("10.0.0.2")
+
+	release1, err := manager.AcquireSession(0, "a@example.com", ip1)
+	common.Must(err)
+	release2, err := manager.AcquireSession(0, "a@example.com", ip1)
+	common.Must(err)
+	if _, err := manager.AcquireSession(0, "a@example.com", ip1); err == nil {
+		t.Error("expect session limit exceeded")
+	}
+	release2()
+	release2()
+	if _, err := manager.AcquireSession(0, "a@example.com", ip2); err == nil {
+		t.Error("expect source IP limit exceeded")
+	}
+	if _, err := manager.AcquireSession(0, "b@example.com", ip2); err != nil {
+		t.Error("expect no limit across users, but got ", err)
+	}
+	release1()
+	release3, err := manager.AcquireSession(0, "a@example.com", ip2)
+	common.Must(err)
+	release3()
+
+	if _, err := manager.AcquireSession(1, "c@example.com", ip1); err != nil {
+		t.Error("expect no limit at level 1, but got ", err)
+	}
+}
@@ -2,6 +2,8 @@ package command
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/core"
@@ -33,14 +35,10 @@ func getInbound(handler inbound.Handler) (proxy.Inbound, error) {
 
 // ApplyInbound implements InboundOperation.
 func (op *AddUserOperation) ApplyInbound(ctx context.Context, handler inbound.Handler) error {
-	p, err := getInbound(handler)
+	um, err := getUserManager(handler)
 	if err != nil {
 		return err
 	}
-	um, ok := p.(proxy.UserManager)
-	if !ok {
-		return newError("proxy is not a UserManager")
-	}
 	mUser, err := op.User.ToMemoryUser()
 	if err != nil {
 		return newError("failed to parse user").Base(err)
@@ -50,15 +48,23 @@ func (op *AddUserOperation) ApplyInbound(ctx context.Context, handler inbound.Ha
 
 // ApplyInbound implements InboundOperation.
 func (op *RemoveUserOperation) ApplyInbound(ctx context.Context, handler inbound.Handler) error {
-	p, err := getInbound(handler)
+	um, err := getUserManager(handler)
 	if err != nil {
 		return err
 	}
+	return um.RemoveUser(ctx, op.Email)
+}
+
+func getUserManager(handler inbound.Handler) (proxy.UserManager, error) {
+	p, err := getInbound(handler)
+	if err != nil {
+		return nil, err
+	}
 	um, ok := p.(proxy.UserManager)
 	if !ok {
-		return newError("proxy is not a UserManager")
+		return nil, newError("proxy is not a UserManager")
 	}
-	return um.RemoveUser(ctx, op.Email)
+	return um, nil
 }
 
 type handlerServer struct {
@@ -97,6 +103,46 @@ func (s *handlerServer) AlterInbound(ctx context.Context, request *AlterInboundR
 	return &AlterInboundResponse{}, operation.ApplyInbound(ctx, handler)
 }
 
+func (s *handlerServer) ListInboundUsers(ctx context.Context, request *ListInboundUsersRequest) (*ListInboundUsersResponse, error) {
+	handler, err := s.ihm.GetHandler(ctx, request.Tag)
+	if err != nil {
+		return nil, newError("failed to get handler: ", request.Tag).Base(err)
+	}
+	um, err := getUserManager(handler)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListInboundUsersResponse{}
+	for _, u := range um.GetUsers(ctx) {
+		if len(request.Email) > 0 && !strings.EqualFold(u.Email, request.Email) {
+			continue
+		}
+		response.Users = append(response.Users, u.ToProtoUser())
+	}
+	if len(request.Email) > 0 && len(response.Users) == 0 {
+		return nil, newError("user ", request.Email, " not found in inbound ", request.Tag)
+	}
+	sort.Slice(response.Users, func(i, j int) bool {
+		return response.Users[i].Email < response.Users[j].Email
+	})
+	return response, nil
+}
+
+func (s *handlerServer) GetInboundUsersCount(ctx context.Context, request *GetInboundUsersCountRequest) (*GetInboundUsersCountResponse, error) {
+	handler, err := s.ihm.GetHandler(ctx, request.Tag)
+	if err != nil {
+		return nil, newError("failed to get handler: ", request.Tag).Base(err)
+	}
+	um, err := getUserManager(handler)
+	if err != nil {
+		return nil, err
+	}
+	return &GetInboundUsersCountResponse{
+		Count: um.GetUsersCount(ctx),
+	}, nil
+}
+
 func (s *handlerServer) AddOutbound(ctx context.Context, request *AddOutboundRequest) (*AddOutboundResponse, error) {
 	if err := core.AddOutboundHandler(s.s, request.Outbound); err != nil {
 		return nil, err
@@ -2,6 +2,7 @@ package router
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/xtls/xray-core/common/dice"
 	"github.com/xtls/xray-core/features/extension"
@@ -24,27 +25,51 @@ func (s *RandomStrategy) PickOutbound(tags []string) string {
 }
 
 type Balancer struct {
-	selectors []string
-	strategy  BalancingStrategy
-	ohm       outbound.Manager
+	selectors   []string
+	strategy    BalancingStrategy
+	ohm         outbound.Manager
+	fallbackTag string
+	override    atomic.Value // string
 }
 
 func (b *Balancer) PickOutbound() (string, error) {
+	if target := b.GetOverrideTarget(); len(target) > 0 {
+		return target, nil
+	}
 	hs, ok := b.ohm.(outbound.HandlerSelector)
 	if !ok {
 		return "", newError("outbound.Manager is not a HandlerSelector")
 	}
 	tags := hs.Select(b.selectors)
 	if len(tags) == 0 {
+		if len(b.fallbackTag) > 0 {
+			newError("no available outbounds selected, using fallback ", b.fallbackTag).AtInfo().WriteToLog()
+			return b.fallbackTag, nil
+		}
 		return "", newError("no available outbounds selected")
 	}
 	tag := b.strategy.PickOutbound(tags)
 	if tag == "" {
+		if len(b.fallbackTag) > 0 {
+			newError("balancing strategy returns empty tag, using fallback ", b.fallbackTag).AtInfo().WriteToLog()
+			return b.fallbackTag, nil
+		}
 		return "", newError("balancing strategy returns empty tag")
 	}
 	return tag, nil
 }
 
+// SetOverrideTarget makes the balancer always pick target. An empty target clears the override.
+func (b *Balancer) SetOverrideTarget(target string) {
+	b.override.Store(target)
+}
+
+// GetOverrideTarget returns the target set by SetOverrideTarget, if any.
+func (b *Balancer) GetOverrideTarget() string {
+	target, _ := b.override.Load().(string)
+	return target
+}
+
 func (b *Balancer) InjectContext(ctx context.Context) {
 	if contextReceiver, ok := b.strategy.(extension.ContextReceiver); ok {
 		contextReceiver.InjectContext(ctx)
@@ -6,6 +6,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/routing"
@@ -31,7 +32,14 @@ func (s *routingServer) TestRoute(ctx context.Context, request *TestRouteRequest
 	if request.RoutingContext == nil {
 		return nil, newError("Invalid routing request.")
 	}
-	route, err := s.router.PickRoute(AsRoutingContext(request.RoutingContext))
+	var route routing.Route
+	var err error
+	if r, ok := s.router.(*router.Router); ok {
+		// Testing a route is not a hit of the rule.
+		route, err = r.TestRoute(AsRoutingContext(request.RoutingContext))
+	} else {
+		route, err = s.router.PickRoute(AsRoutingContext(request.RoutingContext))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +80,71 @@ func (s *routingServer) SubscribeRoutingStats(request *SubscribeRoutingStatsRequ
 	}
 }
 
+// getRouter returns the running router if it supports rule management.
+func (s *routingServer) getRouter() (*router.Router, error) {
+	r, ok := s.router.(*router.Router)
+	if !ok {
+		return nil, newError("rule management only works with its own router.Router.")
+	}
+	return r, nil
+}
+
+func (s *routingServer) AddRule(ctx context.Context, request *AddRuleRequest) (*AddRuleResponse, error) {
+	if request.Config == nil {
+		return nil, newError("Invalid add rule request.")
+	}
+	r, err := s.getRouter()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.AddRule(request.Config, request.ShouldAppend); err != nil {
+		return nil, err
+	}
+	return &AddRuleResponse{}, nil
+}
+
+func (s *routingServer) RemoveRule(ctx context.Context, request *RemoveRuleRequest) (*RemoveRuleResponse, error) {
+	r, err := s.getRouter()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.RemoveRule(request.RuleTag); err != nil {
+		return nil, err
+	}
+	return &RemoveRuleResponse{}, nil
+}
+
+func (s *routingServer) ListRules(ctx context.Context, request *ListRulesRequest) (*ListRulesResponse, error) {
+	r, err := s.getRouter()
+	if err != nil {
+		return nil, err
+	}
+	response := &ListRulesResponse{}
+	for _, rule := range r.ListRules() {
+		info := &RoutingRuleInfo{
+			RuleTag: rule.RuleTag,
+		}
+		if rule.Balancer != nil {
+			info.BalancerTag = rule.BalancerTag
+		} else {
+			info.OutboundTag = rule.Tag
+		}
+		response.Rules = append(response.Rules, info)
+	}
+	return response, nil
+}
+
+func (s *routingServer) OverrideBalancerTarget(ctx context.Context, request *OverrideBalancerTargetRequest) (*OverrideBalancerTargetResponse, error) {
+	r, err := s.getRouter()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.OverrideBalancerTarget(request.BalancerTag, request.Target); err != nil {
+		return nil, err
+	}
+	return &OverrideBalancerTargetResponse{}, nil
+}
+
 func (s *routingServer) mustEmbedUnimplementedRoutingServiceServer() {}
 
 type service struct {
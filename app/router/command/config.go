@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/process"
 	"github.com/xtls/xray-core/features/routing"
 )
 
@@ -35,6 +36,12 @@ func (c routingContext) GetSkipDNSResolve() bool {
 	return false
 }
 
+// GetSourceProcess is a mock implementation here to match the interface, as the process is
+// only found for connections of this instance.
+func (c routingContext) GetSourceProcess() *process.Info {
+	return nil
+}
+
 // AsRoutingContext converts a protobuf RoutingContext into an implementation of routing.Context.
 func AsRoutingContext(r *RoutingContext) routing.Context {
 	return routingContext{r}
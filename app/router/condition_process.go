@@ -0,0 +1,84 @@
+package router
+
+import (
+	"github.com/xtls/xray-core/features/routing"
+)
+
+// ProcessNameMatcher matches the file name of the executable of the local process.
+type ProcessNameMatcher struct {
+	names []string
+}
+
+func NewProcessNameMatcher(names []string) *ProcessNameMatcher {
+	return &ProcessNameMatcher{
+		names: names,
+	}
+}
+
+// Apply implements Condition.
+func (m *ProcessNameMatcher) Apply(ctx routing.Context) bool {
+	info := ctx.GetSourceProcess()
+	if info == nil {
+		return false
+	}
+	name := info.Name()
+	if len(name) == 0 {
+		return false
+	}
+	for _, n := range m.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ProcessPathMatcher matches the full path of the executable of the local process.
+type ProcessPathMatcher struct {
+	paths []string
+}
+
+func NewProcessPathMatcher(paths []string) *ProcessPathMatcher {
+	return &ProcessPathMatcher{
+		paths: paths,
+	}
+}
+
+// Apply implements Condition.
+func (m *ProcessPathMatcher) Apply(ctx routing.Context) bool {
+	info := ctx.GetSourceProcess()
+	if info == nil || len(info.Path) == 0 {
+		return false
+	}
+	for _, p := range m.paths {
+		if p == info.Path {
+			return true
+		}
+	}
+	return false
+}
+
+// UIDMatcher matches the user owning the socket of the local process.
+type UIDMatcher struct {
+	uids []uint32
+}
+
+func NewUIDMatcher(uids []uint32) *UIDMatcher {
+	return &UIDMatcher{
+		uids: uids,
+	}
+}
+
+// Apply implements Condition.
+func (m *UIDMatcher) Apply(ctx routing.Context) bool {
+	info := ctx.GetSourceProcess()
+	if info == nil {
+		return false
+	}
+	for _, uid := range m.uids {
+		if uid == info.UID {
+			return true
+		}
+	}
+	return false
+}
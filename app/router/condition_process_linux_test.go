@@ -0,0 +1,50 @@
+//go:build linux
+// +build linux
+
+package router_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+)
+
+func TestProcessMatchers(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+
+	executable, err := os.Executable()
+	common.Must(err)
+
+	local := withInbound(&session.Inbound{Source: net.DestinationFromAddr(conn.LocalAddr())})
+	unknown := withInbound(&session.Inbound{Source: net.TCPDestination(net.ParseAddress("192.0.2.1"), 1)})
+	cases := []struct {
+		cond   Condition
+		output bool
+	}{
+		{NewProcessNameMatcher([]string{"firefox", filepath.Base(executable)}), true},
+		{NewProcessNameMatcher([]string{"firefox"}), false},
+		{NewProcessPathMatcher([]string{executable}), true},
+		{NewProcessPathMatcher([]string{"/usr/bin/" + filepath.Base(executable)}), false},
+		{NewUIDMatcher([]uint32{uint32(os.Getuid())}), true},
+		{NewUIDMatcher([]uint32{uint32(os.Getuid()) + 1}), false},
+	}
+	for i, test := range cases {
+		if actual := test.cond.Apply(local); actual != test.output {
+			t.Error("test case ", i, ": expect ", test.output, " but got ", actual)
+		}
+		if test.cond.Apply(unknown) {
+			t.Error("test case ", i, ": expect unknown process not to match")
+		}
+	}
+}
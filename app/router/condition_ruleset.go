@@ -0,0 +1,220 @@
+package router
+
+import (
+	"bufio"
+	gonet "net"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/features/routing"
+)
+
+// ruleSetCheckInterval is the interval to check whether rule-set files are changed.
+const ruleSetCheckInterval = 10 * time.Second
+
+// ruleSetContent is the loaded content of a rule-set file. Matchers are nil if there are no
+// domains or IPs in it.
+type ruleSetContent struct {
+	domains *DomainMatcher
+	ips     *GeoIPMatcher
+}
+
+// ruleSet is a rule-set file, whose content is swapped atomically once the file is changed.
+type ruleSet struct {
+	config  *RuleSet
+	content atomic.Pointer[ruleSetContent]
+
+	// modTime and size are only accessed by reload, which is never called concurrently.
+	modTime time.Time
+	size    int64
+}
+
+// reload loads the file again if it is changed since last loaded, and returns whether it is reloaded.
+// The content is kept if the file fails to load.
+func (s *ruleSet) reload() (bool, error) {
+	info, err := os.Stat(s.config.Path)
+	if err != nil {
+		return false, newError("failed to read rule set ", s.config.Path).Base(err)
+	}
+	if s.content.Load() != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return false, nil
+	}
+
+	data, err := loadRuleSetData(s.config)
+	if err != nil {
+		return false, newError("failed to read rule set ", s.config.Path).Base(err)
+	}
+	content := &ruleSetContent{}
+	if len(data.Domain) > 0 {
+		if content.domains, err = NewMphMatcherGroup(data.Domain); err != nil {
+			return false, newError("failed to load domains of rule set ", s.config.Path).Base(err)
+		}
+	}
+	if len(data.Cidr) > 0 {
+		content.ips = &GeoIPMatcher{}
+		if err := content.ips.Init(data.Cidr); err != nil {
+			return false, newError("failed to load IPs of rule set ", s.config.Path).Base(err)
+		}
+	}
+
+	s.content.Store(content)
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	newError("loaded ", len(data.Domain), " domains and ", len(data.Cidr), " IPs from rule set ", s.config.Path).AtInfo().WriteToLog()
+	return true, nil
+}
+
+func loadRuleSetData(config *RuleSet) (*RuleSetData, error) {
+	if config.Format == RuleSet_Binary {
+		b, err := os.ReadFile(config.Path)
+		if err != nil {
+			return nil, err
+		}
+		data := new(RuleSetData)
+		if err := proto.Unmarshal(b, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	file, err := os.Open(config.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data := new(RuleSetData)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		switch config.Format {
+		case RuleSet_DomainList:
+			data.Domain = append(data.Domain, parseRuleSetDomain(line))
+		case RuleSet_CIDRList:
+			cidr, err := parseRuleSetCIDR(line)
+			if err != nil {
+				return nil, err
+			}
+			data.Cidr = append(data.Cidr, cidr)
+		default:
+			return nil, newError("unknown rule set format ", config.Format)
+		}
+	}
+	return data, scanner.Err()
+}
+
+// parseRuleSetDomain parses a domain optionally prefixed with its type.
+func parseRuleSetDomain(line string) *Domain {
+	domain := &Domain{
+		Type:  Domain_Domain,
+		Value: line,
+	}
+	switch {
+	case strings.HasPrefix(line, "domain:"):
+		domain.Value = line[7:]
+	case strings.HasPrefix(line, "full:"):
+		domain.Type = Domain_Full
+		domain.Value = line[5:]
+	case strings.HasPrefix(line, "keyword:"):
+		domain.Type = Domain_Plain
+		domain.Value = line[8:]
+	case strings.HasPrefix(line, "regexp:"):
+		domain.Type = Domain_Regex
+		domain.Value = line[7:]
+	}
+	if domain.Type != Domain_Regex {
+		domain.Value = strings.ToLower(domain.Value)
+	}
+	return domain
+}
+
+// parseRuleSetCIDR parses a CIDR, or an IP as a CIDR of the single IP.
+func parseRuleSetCIDR(line string) (*CIDR, error) {
+	if !strings.Contains(line, "/") {
+		ip := net.ParseIP(line)
+		if ip == nil {
+			return nil, newError("invalid IP: ", line)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		return &CIDR{Ip: ip, Prefix: uint32(len(ip) * 8)}, nil
+	}
+
+	_, ipNet, err := gonet.ParseCIDR(line)
+	if err != nil {
+		return nil, newError("invalid CIDR: ", line).Base(err)
+	}
+	ip := ipNet.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	prefix, _ := ipNet.Mask.Size()
+	return &CIDR{Ip: ip, Prefix: uint32(prefix)}, nil
+}
+
+// RuleSetMatcher matches the target domain and IPs with rule sets.
+type RuleSetMatcher struct {
+	sets []*ruleSet
+}
+
+// NewRuleSetMatcher loads the rule sets. They are reloaded once changed by Reload.
+func NewRuleSetMatcher(configs []*RuleSet) (*RuleSetMatcher, error) {
+	m := &RuleSetMatcher{}
+	for _, config := range configs {
+		s := &ruleSet{config: config}
+		if _, err := s.reload(); err != nil {
+			return nil, err
+		}
+		m.sets = append(m.sets, s)
+	}
+	return m, nil
+}
+
+// Reload reloads the changed rule sets.
+func (m *RuleSetMatcher) Reload() {
+	for _, s := range m.sets {
+		if _, err := s.reload(); err != nil {
+			newError("rule set is not reloaded").Base(err).AtWarning().WriteToLog()
+		}
+	}
+}
+
+// Apply implements Condition.
+func (m *RuleSetMatcher) Apply(ctx routing.Context) bool {
+	domain := ctx.GetTargetDomain()
+	var ips []net.IP
+	resolved := false
+	for _, s := range m.sets {
+		content := s.content.Load()
+		if content.domains != nil && len(domain) > 0 && content.domains.ApplyDomain(domain) {
+			return true
+		}
+		if content.ips == nil {
+			continue
+		}
+		// Target IPs may be resolved from the domain, so they are only got when needed.
+		if !resolved {
+			ips = ctx.GetTargetIPs()
+			resolved = true
+		}
+		for _, ip := range ips {
+			if content.ips.Match(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
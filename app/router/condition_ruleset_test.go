@@ -0,0 +1,93 @@
+package router_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/features/routing"
+)
+
+func withTarget(dest net.Destination) routing.Context {
+	return withOutbound(&session.Outbound{Target: dest})
+}
+
+func TestRuleSetMatcher(t *testing.T) {
+	dir := t.TempDir()
+	domainPath := filepath.Join(dir, "domains.txt")
+	ipPath := filepath.Join(dir, "ips.txt")
+	binaryPath := filepath.Join(dir, "rules.dat")
+
+	common.Must(os.WriteFile(domainPath, []byte(`# abuse domains
+Example.com
+full:tracker.example.org
+keyword:malware
+regexp:^ad[0-9]+\.
+`), 0o644))
+	common.Must(os.WriteFile(ipPath, []byte(`10.0.0.0/8
+192.168.1.1 # single IP
+2001:db8::/32
+`), 0o644))
+	data, err := proto.Marshal(&RuleSetData{
+		Domain: []*Domain{{Type: Domain_Full, Value: "binary.example.net"}},
+		Cidr:   []*CIDR{{Ip: []byte{172, 16, 0, 0}, Prefix: 12}},
+	})
+	common.Must(err)
+	common.Must(os.WriteFile(binaryPath, data, 0o644))
+
+	matcher, err := NewRuleSetMatcher([]*RuleSet{
+		{Path: domainPath},
+		{Path: ipPath, Format: RuleSet_CIDRList},
+		{Path: binaryPath, Format: RuleSet_Binary},
+	})
+	common.Must(err)
+
+	cases := []struct {
+		input  routing.Context
+		output bool
+	}{
+		{withTarget(net.TCPDestination(net.DomainAddress("www.example.com"), 80)), true},
+		{withTarget(net.TCPDestination(net.DomainAddress("example.com"), 80)), true},
+		{withTarget(net.TCPDestination(net.DomainAddress("tracker.example.org"), 80)), true},
+		{withTarget(net.TCPDestination(net.DomainAddress("www.tracker.example.org"), 80)), false},
+		{withTarget(net.TCPDestination(net.DomainAddress("get.malware.net"), 80)), true},
+		{withTarget(net.TCPDestination(net.DomainAddress("ad12.example.net"), 80)), true},
+		{withTarget(net.TCPDestination(net.DomainAddress("binary.example.net"), 80)), true},
+		{withTarget(net.TCPDestination(net.DomainAddress("example.net"), 80)), false},
+		{withTarget(net.TCPDestination(net.ParseAddress("10.1.2.3"), 80)), true},
+		{withTarget(net.TCPDestination(net.ParseAddress("192.168.1.1"), 80)), true},
+		{withTarget(net.TCPDestination(net.ParseAddress("192.168.1.2"), 80)), false},
+		{withTarget(net.TCPDestination(net.ParseAddress("2001:db8::1"), 80)), true},
+		{withTarget(net.TCPDestination(net.ParseAddress("172.20.0.1"), 80)), true},
+		{withTarget(net.TCPDestination(net.ParseAddress("8.8.8.8"), 80)), false},
+	}
+	for _, test := range cases {
+		if actual := matcher.Apply(test.input); actual != test.output {
+			t.Error("test case ", test.input.GetTargetDomain(), test.input.GetTargetIPs(), ": expect ", test.output, " but got ", actual)
+		}
+	}
+
+	// Changed files are reloaded, and the content is kept if a file fails to load.
+	common.Must(os.WriteFile(domainPath, []byte("example.net\n"), 0o644))
+	common.Must(os.WriteFile(ipPath, []byte("invalid\n"), 0o644))
+	future := time.Now().Add(time.Second)
+	common.Must(os.Chtimes(domainPath, future, future))
+	common.Must(os.Chtimes(ipPath, future, future))
+	matcher.Reload()
+
+	if !matcher.Apply(withTarget(net.TCPDestination(net.DomainAddress("example.net"), 80))) {
+		t.Error("expect reloaded domain to match")
+	}
+	if matcher.Apply(withTarget(net.TCPDestination(net.DomainAddress("example.com"), 80))) {
+		t.Error("expect removed domain not to match")
+	}
+	if !matcher.Apply(withTarget(net.TCPDestination(net.ParseAddress("10.1.2.3"), 80))) {
+		t.Error("expect IPs to be kept after failed reload")
+	}
+}
@@ -0,0 +1,80 @@
+package router
+
+import (
+	"time"
+
+	"github.com/xtls/xray-core/features/routing"
+)
+
+const minutesPerDay = 24 * 60
+
+// ScheduleMatcher matches the time the connection is routed with a schedule.
+type ScheduleMatcher struct {
+	weekdays [7]bool
+	ranges   []*Schedule_TimeRange
+	location *time.Location
+}
+
+func NewScheduleMatcher(schedule *Schedule) (*ScheduleMatcher, error) {
+	m := &ScheduleMatcher{
+		location: time.Local,
+	}
+	if len(schedule.Weekday) == 0 {
+		for i := range m.weekdays {
+			m.weekdays[i] = true
+		}
+	}
+	for _, day := range schedule.Weekday {
+		if day >= 7 {
+			return nil, newError("invalid weekday: ", day)
+		}
+		m.weekdays[day] = true
+	}
+	for _, r := range schedule.TimeRange {
+		if r.From >= minutesPerDay || r.To > minutesPerDay || r.From == r.To {
+			return nil, newError("invalid time range: ", r.From, "-", r.To)
+		}
+		m.ranges = append(m.ranges, r)
+	}
+	if len(schedule.TimeZone) > 0 {
+		location, err := time.LoadLocation(schedule.TimeZone)
+		if err != nil {
+			return nil, newError("invalid time zone: ", schedule.TimeZone).Base(err)
+		}
+		m.location = location
+	}
+	return m, nil
+}
+
+// Match returns whether t is in the schedule.
+func (m *ScheduleMatcher) Match(t time.Time) bool {
+	t = t.In(m.location)
+	day := t.Weekday()
+	if len(m.ranges) == 0 {
+		return m.weekdays[day]
+	}
+
+	minute := uint32(t.Hour()*60 + t.Minute())
+	yesterday := (day + 6) % 7
+	for _, r := range m.ranges {
+		if r.From < r.To {
+			if m.weekdays[day] && minute >= r.From && minute < r.To {
+				return true
+			}
+			continue
+		}
+		// The range spans midnight.
+		if m.weekdays[day] && minute >= r.From {
+			return true
+		}
+		if m.weekdays[yesterday] && minute < r.To {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply implements Condition.
+func (m *ScheduleMatcher) Apply(ctx routing.Context) bool {
+	return m.Match(time.Now())
+}
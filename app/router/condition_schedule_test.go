@@ -0,0 +1,57 @@
+package router_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
+)
+
+func TestScheduleMatcher(t *testing.T) {
+	// Weekdays from 22:00 to 06:00 of the next day in UTC+8, and all day on Sundays.
+	night, err := NewScheduleMatcher(&Schedule{
+		Weekday: []uint32{1, 2, 3, 4, 5},
+		TimeRange: []*Schedule_TimeRange{
+			{From: 22 * 60, To: 6 * 60},
+		},
+		TimeZone: "Etc/GMT-8",
+	})
+	common.Must(err)
+	sunday, err := NewScheduleMatcher(&Schedule{Weekday: []uint32{0}})
+	common.Must(err)
+
+	zone := time.FixedZone("UTC+8", 8*60*60)
+	cases := []struct {
+		matcher *ScheduleMatcher
+		time    time.Time
+		output  bool
+	}{
+		{night, time.Date(2024, 1, 1, 22, 0, 0, 0, zone), true},   // Monday
+		{night, time.Date(2024, 1, 1, 21, 59, 0, 0, zone), false}, // Monday
+		{night, time.Date(2024, 1, 2, 5, 59, 0, 0, zone), true},   // Tuesday, after Monday night
+		{night, time.Date(2024, 1, 2, 6, 0, 0, 0, zone), false},   // Tuesday
+		{night, time.Date(2024, 1, 1, 5, 0, 0, 0, zone), false},   // Monday, after Sunday night
+		{night, time.Date(2024, 1, 6, 3, 0, 0, 0, zone), true},    // Saturday, after Friday night
+		{night, time.Date(2024, 1, 6, 23, 0, 0, 0, zone), false},  // Saturday
+		{night, time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC), true},
+		{sunday, time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC), true},
+		{sunday, time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC), false},
+	}
+	for i, test := range cases {
+		if actual := test.matcher.Match(test.time); actual != test.output {
+			t.Error("test case ", i, ": expect ", test.output, " but got ", actual)
+		}
+	}
+
+	for _, schedule := range []*Schedule{
+		{Weekday: []uint32{7}},
+		{TimeRange: []*Schedule_TimeRange{{From: 60, To: 60}}},
+		{TimeRange: []*Schedule_TimeRange{{From: 24 * 60, To: 60}}},
+		{TimeZone: "Mars/Olympus_Mons"},
+	} {
+		if _, err := NewScheduleMatcher(schedule); err == nil {
+			t.Error("expect error of invalid schedule ", schedule)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/features/outbound"
 	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/features/stats"
 )
 
 // CIDRList is an alias of []*CIDR to provide sort.Interface.
@@ -46,9 +47,18 @@ func (l *CIDRList) Swap(i int, j int) {
 }
 
 type Rule struct {
-	Tag       string
-	Balancer  *Balancer
-	Condition Condition
+	Tag         string
+	RuleTag     string
+	BalancerTag string
+	Balancer    *Balancer
+	Condition   Condition
+
+	// domainIndexed is whether the domains of the rule are matched by the domain index of the
+	// router, instead of Condition.
+	domainIndexed bool
+
+	// hits is the counter of routes matching the rule, if rule stats are enabled.
+	hits stats.Counter
 }
 
 func (r *Rule) GetTag() (string, error) {
@@ -58,15 +68,28 @@ func (r *Rule) GetTag() (string, error) {
 	return r.Tag, nil
 }
 
-// Apply checks rule matching of current routing context.
-func (r *Rule) Apply(ctx routing.Context) bool {
+// apply checks rule matching of current routing context, except the domains matched by the
+// domain index of the router, which must be checked before.
+func (r *Rule) apply(ctx routing.Context) bool {
 	return r.Condition.Apply(ctx)
 }
 
 func (rr *RoutingRule) BuildCondition() (Condition, error) {
+	return rr.buildCondition(true)
+}
+
+// indexDomains returns whether the domains of the rule can be matched by the domain index shared
+// by all rules, which doesn't apply to rules requiring the linear matcher.
+func (rr *RoutingRule) indexDomains() bool {
+	return len(rr.Domain) > 0 && rr.DomainMatcher != "linear"
+}
+
+// buildCondition builds the condition of the rule, which only includes the domains if withDomain
+// is true.
+func (rr *RoutingRule) buildCondition(withDomain bool) (Condition, error) {
 	conds := NewConditionChan()
 
-	if len(rr.Domain) > 0 {
+	if len(rr.Domain) > 0 && withDomain {
 		switch rr.DomainMatcher {
 		case "linear":
 			matcher, err := NewDomainMatcher(rr.Domain)
@@ -138,10 +161,38 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 		conds.Add(cond)
 	}
 
+	if len(rr.RuleSet) > 0 {
+		cond, err := NewRuleSetMatcher(rr.RuleSet)
+		if err != nil {
+			return nil, err
+		}
+		conds.Add(cond)
+	}
+
 	if len(rr.Protocol) > 0 {
 		conds.Add(NewProtocolMatcher(rr.Protocol))
 	}
 
+	if len(rr.ProcessName) > 0 {
+		conds.Add(NewProcessNameMatcher(rr.ProcessName))
+	}
+
+	if len(rr.ProcessPath) > 0 {
+		conds.Add(NewProcessPathMatcher(rr.ProcessPath))
+	}
+
+	if len(rr.Uid) > 0 {
+		conds.Add(NewUIDMatcher(rr.Uid))
+	}
+
+	if rr.Schedule != nil {
+		cond, err := NewScheduleMatcher(rr.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		conds.Add(cond)
+	}
+
 	if len(rr.Attributes) > 0 {
 		cond, err := NewAttributeMatcher(rr.Attributes)
 		if err != nil {
@@ -150,7 +201,7 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 		conds.Add(cond)
 	}
 
-	if conds.Len() == 0 {
+	if conds.Len() == 0 && len(rr.Domain) == 0 {
 		return nil, newError("this rule has no effective fields").AtWarning()
 	}
 
@@ -158,21 +209,36 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 }
 
 func (br *BalancingRule) Build(ohm outbound.Manager) (*Balancer, error) {
+	var settings interface{}
+	if br.StrategySettings != nil {
+		var err error
+		if settings, err = br.StrategySettings.GetInstance(); err != nil {
+			return nil, newError("failed to load settings of balancer ", br.Tag).Base(err)
+		}
+	}
+
+	var strategy BalancingStrategy
 	switch br.Strategy {
 	case "leastPing":
-		return &Balancer{
-			selectors: br.OutboundSelector,
-			strategy:  &LeastPingStrategy{},
-			ohm:       ohm,
-		}, nil
+		strategy = &LeastPingStrategy{}
+	case "leastLoad":
+		s, _ := settings.(*StrategyLeastLoadConfig)
+		strategy = &LeastLoadStrategy{settings: s}
+	case "roundRobin":
+		strategy = &RoundRobinStrategy{}
+	case "weighted":
+		s, _ := settings.(*StrategyWeightedConfig)
+		strategy = &WeightedStrategy{settings: s}
 	case "random":
 		fallthrough
 	default:
-		return &Balancer{
-			selectors: br.OutboundSelector,
-			strategy:  &RandomStrategy{},
-			ohm:       ohm,
-		}, nil
-
+		strategy = &RandomStrategy{}
 	}
+
+	return &Balancer{
+		selectors:   br.OutboundSelector,
+		strategy:    strategy,
+		ohm:         ohm,
+		fallbackTag: br.FallbackTag,
+	}, nil
 }
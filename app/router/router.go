@@ -4,21 +4,45 @@ package router
 
 import (
 	"context"
+	"strings"
+	"sync"
 
 	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/strmatcher"
+	"github.com/xtls/xray-core/common/task"
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/outbound"
 	"github.com/xtls/xray-core/features/routing"
 	routing_dns "github.com/xtls/xray-core/features/routing/dns"
+	"github.com/xtls/xray-core/features/stats"
 )
 
+// unmatchedCounterName is the name of the counter of routes matching no rules.
+const unmatchedCounterName = "router>>>unmatched>>>route>>>hits"
+
+// ruleCounterName returns the name of the hit counter of the rule tagged with tag.
+func ruleCounterName(tag string) string {
+	return "rule>>>" + tag + ">>>route>>>hits"
+}
+
 // Router is an implementation of routing.Router.
 type Router struct {
+	access         sync.RWMutex
 	domainStrategy Config_DomainStrategy
 	rules          []*Rule
 	balancers      map[string]*Balancer
 	dns            dns.Client
+	ohm            outbound.Manager
+	ctx            context.Context
+	ruleSetCheck   *task.Periodic
+	// domainIndex matches the target domain with the domains of all rules, nil if there are none.
+	domainIndex *strmatcher.GroupMatcher
+
+	// stats is the manager of hit counters, which are only registered if ruleStats is true.
+	stats     stats.Manager
+	ruleStats bool
+	unmatched stats.Counter
 }
 
 // Route is an implementation of routing.Route.
@@ -32,44 +56,295 @@ type Route struct {
 func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm outbound.Manager) error {
 	r.domainStrategy = config.DomainStrategy
 	r.dns = d
+	r.ohm = ohm
+	r.ctx = ctx
+	r.ruleSetCheck = &task.Periodic{
+		Interval: ruleSetCheckInterval,
+		Execute:  r.reloadRuleSets,
+	}
+
+	balancers, err := r.buildBalancers(config, nil)
+	if err != nil {
+		return err
+	}
+	rules, err := buildRules(config, balancers)
+	if err != nil {
+		return err
+	}
+	domainIndex, err := buildDomainIndex(nil, nil, config.Rule, 0)
+	if err != nil {
+		return err
+	}
+	r.setRuleStats(config.RuleStats)
+	r.setRules(rules, domainIndex)
+	r.balancers = balancers
+
+	return nil
+}
 
-	r.balancers = make(map[string]*Balancer, len(config.BalancingRule))
+// buildBalancers builds the balancers in config on top of existing ones.
+func (r *Router) buildBalancers(config *Config, existing map[string]*Balancer) (map[string]*Balancer, error) {
+	balancers := make(map[string]*Balancer, len(existing)+len(config.BalancingRule))
+	for tag, balancer := range existing {
+		balancers[tag] = balancer
+	}
 	for _, rule := range config.BalancingRule {
-		balancer, err := rule.Build(ohm)
+		if _, found := balancers[rule.Tag]; found {
+			return nil, newError("duplicate balancer tag ", rule.Tag)
+		}
+		balancer, err := rule.Build(r.ohm)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		balancer.InjectContext(ctx)
-		r.balancers[rule.Tag] = balancer
+		balancer.InjectContext(r.ctx)
+		balancers[rule.Tag] = balancer
 	}
+	return balancers, nil
+}
 
-	r.rules = make([]*Rule, 0, len(config.Rule))
+func buildRules(config *Config, balancers map[string]*Balancer) ([]*Rule, error) {
+	rules := make([]*Rule, 0, len(config.Rule))
 	for _, rule := range config.Rule {
-		cond, err := rule.BuildCondition()
+		indexDomains := rule.indexDomains()
+		cond, err := rule.buildCondition(!indexDomains)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		rr := &Rule{
 			Condition: cond,
 			Tag:       rule.GetTag(),
+			RuleTag:   rule.GetRuleTag(),
 		}
+		rr.domainIndexed = indexDomains
 		btag := rule.GetBalancingTag()
 		if len(btag) > 0 {
-			brule, found := r.balancers[btag]
+			brule, found := balancers[btag]
 			if !found {
-				return newError("balancer ", btag, " not found")
+				return nil, newError("balancer ", btag, " not found")
 			}
 			rr.Balancer = brule
+			rr.BalancerTag = btag
+		}
+		rules = append(rules, rr)
+	}
+	return rules, nil
+}
+
+// AddRule adds the rules and balancers in config to the router. If shouldAppend is false,
+// the current rules and balancers are replaced instead.
+func (r *Router) AddRule(config *Config, shouldAppend bool) error {
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	return r.addRule(config, shouldAppend)
+}
+
+// Reload implements features.Reloadable. It replaces the domain strategy, rules and balancers.
+func (r *Router) Reload(config interface{}) error {
+	c, ok := config.(*Config)
+	if !ok {
+		return newError("not a router config")
+	}
+
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	// Rule counters are updated by addRule according to the new setting.
+	ruleStats := r.ruleStats
+	r.ruleStats = c.RuleStats
+	if err := r.addRule(c, false); err != nil {
+		r.ruleStats = ruleStats
+		return err
+	}
+	r.setRuleStats(c.RuleStats)
+	r.domainStrategy = c.DomainStrategy
+	return nil
+}
+
+func (r *Router) addRule(config *Config, shouldAppend bool) error {
+	var existing map[string]*Balancer
+	if shouldAppend {
+		existing = r.balancers
+	}
+	balancers, err := r.buildBalancers(config, existing)
+	if err != nil {
+		return err
+	}
+	rules, err := buildRules(config, balancers)
+	if err != nil {
+		return err
+	}
+
+	var domainIndex *strmatcher.GroupMatcher
+	if shouldAppend {
+		domainIndex, err = buildDomainIndex(r.domainIndex, func(i uint32) (uint32, bool) { return i, true }, config.Rule, len(r.rules))
+		rules = append(append(make([]*Rule, 0, len(r.rules)+len(rules)), r.rules...), rules...)
+	} else {
+		domainIndex, err = buildDomainIndex(nil, nil, config.Rule, 0)
+	}
+	if err != nil {
+		return err
+	}
+	ruleTags := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if len(rule.RuleTag) == 0 {
+			continue
+		}
+		if ruleTags[rule.RuleTag] {
+			return newError("duplicate rule tag ", rule.RuleTag)
+		}
+		ruleTags[rule.RuleTag] = true
+	}
+
+	r.setRules(rules, domainIndex)
+	r.balancers = balancers
+	return nil
+}
+
+// RemoveRule removes the rules tagged with tag from the router.
+func (r *Router) RemoveRule(tag string) error {
+	if len(tag) == 0 {
+		return newError("empty rule tag")
+	}
+
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	rules := make([]*Rule, 0, len(r.rules))
+	// positions are the new positions of current rules, or -1 for removed ones.
+	positions := make([]int, len(r.rules))
+	for i, rule := range r.rules {
+		positions[i] = -1
+		if rule.RuleTag != tag {
+			positions[i] = len(rules)
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == len(r.rules) {
+		return newError("rule ", tag, " not found")
+	}
+	domainIndex, err := buildDomainIndex(r.domainIndex, func(i uint32) (uint32, bool) {
+		return uint32(positions[i]), positions[i] >= 0
+	}, nil, 0)
+	if err != nil {
+		return err
+	}
+	r.setRules(rules, domainIndex)
+	return nil
+}
+
+// setRuleStats enables or disables counting routes matching no rules. The hit counters of rules
+// are updated by setRules.
+func (r *Router) setRuleStats(enabled bool) {
+	r.ruleStats = enabled
+	if enabled && r.stats != nil {
+		if r.unmatched == nil {
+			r.unmatched, _ = stats.GetOrRegisterCounter(r.stats, unmatchedCounterName)
+		}
+	} else if r.unmatched != nil {
+		r.stats.UnregisterCounter(unmatchedCounterName)
+		r.unmatched = nil
+	}
+}
+
+// setRules replaces the current rules and the domain index of them. If rule stats are enabled, hit
+// counters of tagged rules are registered, and those of rules no longer existing are unregistered.
+func (r *Router) setRules(rules []*Rule, domainIndex *strmatcher.GroupMatcher) {
+	used := make(map[string]bool)
+	if r.ruleStats && r.stats != nil {
+		for _, rule := range rules {
+			if len(rule.RuleTag) == 0 {
+				continue
+			}
+			used[rule.RuleTag] = true
+			if rule.hits == nil {
+				rule.hits, _ = stats.GetOrRegisterCounter(r.stats, ruleCounterName(rule.RuleTag))
+			}
+		}
+	}
+	for _, rule := range r.rules {
+		if rule.hits != nil && !used[rule.RuleTag] {
+			r.stats.UnregisterCounter(ruleCounterName(rule.RuleTag))
+		}
+	}
+	r.rules = rules
+	r.domainIndex = domainIndex
+}
+
+// buildDomainIndex builds the index of domains of rules, where the group of each domain is the
+// position of its rule, starting at offset. Domains shared by rules, such as the same geosite list,
+// are only stored once. If current is not nil, its domains are kept, with their groups mapped by
+// position to the new positions of current rules, or dropped if position returns false.
+func buildDomainIndex(current *strmatcher.GroupMatcher, position func(uint32) (uint32, bool), rules []*RoutingRule, offset int) (*strmatcher.GroupMatcher, error) {
+	var index *strmatcher.GroupMatcher
+	if current != nil {
+		index = strmatcher.NewGroupMatcher()
+		index.AddGroupMatcher(current, position)
+	}
+	count := 0
+	for i, rule := range rules {
+		if !rule.indexDomains() {
+			continue
+		}
+		for _, d := range rule.Domain {
+			matcherType, f := matcherTypeMap[d.Type]
+			if !f {
+				return nil, newError("unsupported domain type", d.Type)
+			}
+			if index == nil {
+				index = strmatcher.NewGroupMatcher()
+			}
+			if err := index.Add(d.Value, matcherType, uint32(offset+i)); err != nil {
+				return nil, newError("failed to build domain condition").Base(err)
+			}
+			count++
 		}
-		r.rules = append(r.rules, rr)
 	}
+	if index == nil {
+		return nil, nil
+	}
+	index.Build()
+	newError("domain index is built with ", count, " domains of ", len(rules), " new rules").AtDebug().WriteToLog()
+	return index, nil
+}
+
+// ListRules returns the current rule chain in order.
+func (r *Router) ListRules() []*Rule {
+	r.access.RLock()
+	defer r.access.RUnlock()
 
+	return r.rules
+}
+
+// OverrideBalancerTarget makes the balancer with the given tag always pick target.
+// An empty target clears the override.
+func (r *Router) OverrideBalancerTarget(balancerTag string, target string) error {
+	r.access.RLock()
+	balancer, found := r.balancers[balancerTag]
+	r.access.RUnlock()
+	if !found {
+		return newError("balancer ", balancerTag, " not found")
+	}
+	if len(target) > 0 && r.ohm.GetHandler(target) == nil {
+		return newError("outbound ", target, " not found")
+	}
+	balancer.SetOverrideTarget(target)
 	return nil
 }
 
-// PickRoute implements routing.Router.
+// PickRoute implements routing.Router. Hits of rules are counted if rule stats are enabled.
 func (r *Router) PickRoute(ctx routing.Context) (routing.Route, error) {
-	rule, ctx, err := r.pickRouteInternal(ctx)
+	return r.pickRoute(ctx, true)
+}
+
+// TestRoute picks the route like PickRoute, but without counting hits of rules, as the route is
+// not used by any connections.
+func (r *Router) TestRoute(ctx routing.Context) (routing.Route, error) {
+	return r.pickRoute(ctx, false)
+}
+
+func (r *Router) pickRoute(ctx routing.Context, countHits bool) (routing.Route, error) {
+	rule, ctx, err := r.pickRouteInternal(ctx, countHits)
 	if err != nil {
 		return nil, err
 	}
@@ -80,46 +355,102 @@ func (r *Router) PickRoute(ctx routing.Context) (routing.Route, error) {
 	return &Route{Context: ctx, outboundTag: tag}, nil
 }
 
-func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context, error) {
+func (r *Router) pickRouteInternal(ctx routing.Context, countHits bool) (*Rule, routing.Context, error) {
 	// SkipDNSResolve is set from DNS module.
 	// the DOH remote server maybe a domain name,
 	// this prevents cycle resolving dead loop
 	skipDNSResolve := ctx.GetSkipDNSResolve()
 
-	if r.domainStrategy == Config_IpOnDemand && !skipDNSResolve {
+	r.access.RLock()
+	domainStrategy, rules, domainIndex, unmatched := r.domainStrategy, r.rules, r.domainIndex, r.unmatched
+	r.access.RUnlock()
+
+	rule, ctx := r.matchRule(ctx, domainStrategy, rules, domainIndex, skipDNSResolve)
+	if rule == nil {
+		if unmatched != nil && countHits {
+			unmatched.Add(1)
+		}
+		return nil, ctx, common.ErrNoClue
+	}
+	if rule.hits != nil && countHits {
+		rule.hits.Add(1)
+	}
+	return rule, ctx, nil
+}
+
+// matchRule returns the first rule matching ctx, or nil if none matches.
+func (r *Router) matchRule(ctx routing.Context, domainStrategy Config_DomainStrategy, rules []*Rule, domainIndex *strmatcher.GroupMatcher, skipDNSResolve bool) (*Rule, routing.Context) {
+	// Rules with domains matching the target domain, which doesn't change after resolving.
+	var domainMatched []uint32
+	if domain := ctx.GetTargetDomain(); len(domain) > 0 && domainIndex != nil {
+		domainMatched = domainIndex.Match(strings.ToLower(domain))
+	}
+
+	if domainStrategy == Config_IpOnDemand && !skipDNSResolve {
 		ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
 	}
 
-	for _, rule := range r.rules {
-		if rule.Apply(ctx) {
-			return rule, ctx, nil
-		}
+	if rule := firstRule(ctx, rules, domainMatched); rule != nil {
+		return rule, ctx
 	}
 
-	if r.domainStrategy != Config_IpIfNonMatch || len(ctx.GetTargetDomain()) == 0 || skipDNSResolve {
-		return nil, ctx, common.ErrNoClue
+	if domainStrategy != Config_IpIfNonMatch || len(ctx.GetTargetDomain()) == 0 || skipDNSResolve {
+		return nil, ctx
 	}
 
 	ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
 
 	// Try applying rules again if we have IPs.
-	for _, rule := range r.rules {
-		if rule.Apply(ctx) {
-			return rule, ctx, nil
+	return firstRule(ctx, rules, domainMatched), ctx
+}
+
+// firstRule returns the first rule matching ctx, where domainMatched is the indexes of rules in
+// ascending order, whose domains match the target domain.
+func firstRule(ctx routing.Context, rules []*Rule, domainMatched []uint32) *Rule {
+	for i, rule := range rules {
+		if rule.domainIndexed {
+			for len(domainMatched) > 0 && domainMatched[0] < uint32(i) {
+				domainMatched = domainMatched[1:]
+			}
+			if len(domainMatched) == 0 || domainMatched[0] != uint32(i) {
+				continue
+			}
+		}
+		if rule.apply(ctx) {
+			return rule
 		}
 	}
+	return nil
+}
+
+// reloadRuleSets reloads the changed rule sets of current rules.
+func (r *Router) reloadRuleSets() error {
+	r.access.RLock()
+	rules := r.rules
+	r.access.RUnlock()
 
-	return nil, ctx, common.ErrNoClue
+	for _, rule := range rules {
+		conds, ok := rule.Condition.(*ConditionChan)
+		if !ok {
+			continue
+		}
+		for _, cond := range *conds {
+			if m, ok := cond.(*RuleSetMatcher); ok {
+				m.Reload()
+			}
+		}
+	}
+	return nil
 }
 
 // Start implements common.Runnable.
-func (*Router) Start() error {
-	return nil
+func (r *Router) Start() error {
+	return r.ruleSetCheck.Start()
 }
 
 // Close implements common.Closable.
-func (*Router) Close() error {
-	return nil
+func (r *Router) Close() error {
+	return r.ruleSetCheck.Close()
 }
 
 // Type implements common.HasType.
@@ -140,7 +471,8 @@ func (r *Route) GetOutboundTag() string {
 func init() {
 	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
 		r := new(Router)
-		if err := core.RequireFeatures(ctx, func(d dns.Client, ohm outbound.Manager) error {
+		if err := core.RequireFeatures(ctx, func(d dns.Client, ohm outbound.Manager, sm stats.Manager) error {
+			r.stats = sm
 			return r.Init(ctx, config.(*Config), d, ohm)
 		}); err != nil {
 			return nil, err
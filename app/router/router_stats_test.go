@@ -0,0 +1,76 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xtls/xray-core/app/stats"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/session"
+	routing_session "github.com/xtls/xray-core/features/routing/session"
+)
+
+func TestRuleStats(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	tcpRule := &RoutingRule{
+		TargetTag: &RoutingRule_Tag{Tag: "direct"},
+		RuleTag:   "tcp",
+		Networks:  []net.Network{net.Network_TCP},
+	}
+	udpRule := &RoutingRule{
+		TargetTag: &RoutingRule_Tag{Tag: "direct"},
+		Networks:  []net.Network{net.Network_UDP},
+	}
+	r := &Router{stats: m}
+	common.Must(r.Init(context.Background(), &Config{
+		Rule:      []*RoutingRule{tcpRule},
+		RuleStats: true,
+	}, nil, nil))
+
+	pick := func(network net.Network) {
+		ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{
+			Target: net.Destination{Network: network, Address: net.DomainAddress("example.com"), Port: 80},
+		})
+		r.PickRoute(routing_session.AsRoutingContext(ctx))
+	}
+	pick(net.Network_TCP)
+	pick(net.Network_TCP)
+	pick(net.Network_UDP)
+
+	if v := m.GetCounter(ruleCounterName("tcp")).Value(); v != 2 {
+		t.Error("expect 2 hits of rule, but got ", v)
+	}
+	if v := m.GetCounter(unmatchedCounterName).Value(); v != 1 {
+		t.Error("expect 1 unmatched route, but got ", v)
+	}
+
+	// Testing routes doesn't count.
+	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{
+		Target: net.TCPDestination(net.DomainAddress("example.com"), 80),
+	})
+	common.Must2(r.TestRoute(routing_session.AsRoutingContext(ctx)))
+	if v := m.GetCounter(ruleCounterName("tcp")).Value(); v != 2 {
+		t.Error("expect 2 hits of rule after testing route, but got ", v)
+	}
+
+	// Counters are kept for rules still existing, and unregistered for removed rules.
+	common.Must(r.AddRule(&Config{Rule: []*RoutingRule{udpRule}}, true))
+	pick(net.Network_TCP)
+	pick(net.Network_UDP)
+	if v := m.GetCounter(ruleCounterName("tcp")).Value(); v != 3 {
+		t.Error("expect 3 hits of rule, but got ", v)
+	}
+	common.Must(r.RemoveRule("tcp"))
+	if m.GetCounter(ruleCounterName("tcp")) != nil {
+		t.Error("expect counter of removed rule to be unregistered")
+	}
+
+	common.Must(r.Reload(&Config{Rule: []*RoutingRule{tcpRule}}))
+	pick(net.Network_TCP)
+	if m.GetCounter(ruleCounterName("tcp")) != nil || m.GetCounter(unmatchedCounterName) != nil {
+		t.Error("expect counters to be unregistered once rule stats are disabled")
+	}
+}
@@ -205,3 +205,183 @@ func TestIPIfNonMatchIP(t *testing.T) {
 		t.Error("expect tag 'test', bug actually ", tag)
 	}
 }
+
+func TestRuleManagement(t *testing.T) {
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				TargetTag: &RoutingRule_Tag{
+					Tag: "test",
+				},
+				Networks: []net.Network{net.Network_TCP},
+				RuleTag:  "tcp",
+			},
+		},
+	}
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	mockDNS := mocks.NewDNSClient(mockCtl)
+	mockOhm := mocks.NewOutboundManager(mockCtl)
+	mockHs := mocks.NewOutboundHandlerSelector(mockCtl)
+	mockHs.EXPECT().Select(gomock.Eq([]string{"test-"})).Return([]string{"test-a", "test-b"}).AnyTimes()
+	mockOhm.EXPECT().GetHandler("test-b").Return(struct{ outbound.Handler }{})
+	mockOhm.EXPECT().GetHandler("unknown").Return(nil)
+
+	r := new(Router)
+	common.Must(r.Init(context.TODO(), config, mockDNS, &mockOutboundManager{
+		Manager:         mockOhm,
+		HandlerSelector: mockHs,
+	}))
+
+	pick := func() string {
+		ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("example.com"), 80)})
+		route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+		if err != nil {
+			return ""
+		}
+		return route.GetOutboundTag()
+	}
+
+	if tag := pick(); tag != "test" {
+		t.Error("expect tag 'test', but actually ", tag)
+	}
+
+	balanced := &Config{
+		Rule: []*RoutingRule{
+			{
+				TargetTag: &RoutingRule_BalancingTag{
+					BalancingTag: "balance",
+				},
+				Networks: []net.Network{net.Network_TCP},
+				RuleTag:  "balanced",
+			},
+		},
+		BalancingRule: []*BalancingRule{
+			{
+				Tag:              "balance",
+				OutboundSelector: []string{"test-"},
+			},
+		},
+	}
+	common.Must(r.AddRule(balanced, true))
+	if err := r.AddRule(balanced, true); err == nil {
+		t.Error("expect error for duplicate balancer")
+	}
+	if rules := r.ListRules(); len(rules) != 2 || rules[1].RuleTag != "balanced" || rules[1].BalancerTag != "balance" {
+		t.Error("unexpected rules after adding: ", rules)
+	}
+
+	common.Must(r.RemoveRule("tcp"))
+	if err := r.RemoveRule("tcp"); err == nil {
+		t.Error("expect error for removing missing rule")
+	}
+	if tag := pick(); tag != "test-a" && tag != "test-b" {
+		t.Error("expect tag 'test-a' or 'test-b', but actually ", tag)
+	}
+
+	common.Must(r.OverrideBalancerTarget("balance", "test-b"))
+	for i := 0; i < 10; i++ {
+		if tag := pick(); tag != "test-b" {
+			t.Error("expect overridden tag 'test-b', but actually ", tag)
+		}
+	}
+	if err := r.OverrideBalancerTarget("balance", "unknown"); err == nil {
+		t.Error("expect error for unknown outbound")
+	}
+	if err := r.OverrideBalancerTarget("unknown", ""); err == nil {
+		t.Error("expect error for unknown balancer")
+	}
+
+	common.Must(r.AddRule(config, false))
+	if rules := r.ListRules(); len(rules) != 1 || rules[0].RuleTag != "tcp" {
+		t.Error("unexpected rules after replacing: ", rules)
+	}
+	if tag := pick(); tag != "test" {
+		t.Error("expect tag 'test', but actually ", tag)
+	}
+}
+
+func TestDomainIndex(t *testing.T) {
+	example := []*Domain{{Type: Domain_Domain, Value: "example.com"}}
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				TargetTag: &RoutingRule_Tag{Tag: "udp-example"},
+				Domain:    example,
+				Networks:  []net.Network{net.Network_UDP},
+			},
+			{
+				TargetTag: &RoutingRule_Tag{Tag: "ssh"},
+				Networks:  []net.Network{net.Network_TCP},
+				PortList:  &net.PortList{Range: []*net.PortRange{net.SinglePortRange(22)}},
+			},
+			{
+				TargetTag: &RoutingRule_Tag{Tag: "www"},
+				Domain:    []*Domain{{Type: Domain_Full, Value: "WWW.example.com"}},
+				RuleTag:   "www",
+			},
+			{
+				TargetTag: &RoutingRule_Tag{Tag: "example"},
+				Domain:    example,
+			},
+			{
+				TargetTag:     &RoutingRule_Tag{Tag: "linear"},
+				Domain:        []*Domain{{Type: Domain_Plain, Value: "test"}},
+				DomainMatcher: "linear",
+			},
+		},
+	}
+
+	r := new(Router)
+	common.Must(r.Init(context.TODO(), config, nil, nil))
+
+	pick := func(dest net.Destination) string {
+		ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: dest})
+		route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+		if err != nil {
+			return ""
+		}
+		return route.GetOutboundTag()
+	}
+
+	cases := []struct {
+		dest net.Destination
+		tag  string
+	}{
+		{net.TCPDestination(net.DomainAddress("www.example.com"), 80), "www"},
+		{net.TCPDestination(net.DomainAddress("WWW.Example.com"), 80), "www"},
+		{net.UDPDestination(net.DomainAddress("www.example.com"), 80), "udp-example"},
+		{net.TCPDestination(net.DomainAddress("example.com"), 22), "ssh"},
+		{net.TCPDestination(net.DomainAddress("foo.example.com"), 80), "example"},
+		{net.TCPDestination(net.DomainAddress("mytest.org"), 80), "linear"},
+		{net.TCPDestination(net.DomainAddress("example.org"), 80), ""},
+		{net.TCPDestination(net.ParseAddress("1.1.1.1"), 80), ""},
+	}
+	for _, test := range cases {
+		if tag := pick(test.dest); tag != test.tag {
+			t.Error("expect tag '", test.tag, "' for ", test.dest, ", but actually ", tag)
+		}
+	}
+
+	// The index is rebuilt once rules are changed.
+	common.Must(r.RemoveRule("www"))
+	if tag := pick(net.TCPDestination(net.DomainAddress("www.example.com"), 80)); tag != "example" {
+		t.Error("expect tag 'example' after removing rule, but actually ", tag)
+	}
+	common.Must(r.AddRule(&Config{
+		Rule: []*RoutingRule{
+			{
+				TargetTag: &RoutingRule_Tag{Tag: "org"},
+				Domain:    []*Domain{{Type: Domain_Domain, Value: "example.org"}},
+			},
+		},
+	}, true))
+	if tag := pick(net.TCPDestination(net.DomainAddress("www.example.com"), 80)); tag != "example" {
+		t.Error("expect tag 'example' after appending rule, but actually ", tag)
+	}
+	if tag := pick(net.TCPDestination(net.DomainAddress("example.org"), 80)); tag != "org" {
+		t.Error("expect tag 'org' after appending rule, but actually ", tag)
+	}
+}
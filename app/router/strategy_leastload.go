@@ -0,0 +1,96 @@
+package router
+
+import (
+	"context"
+	"sort"
+
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/common/dice"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/extension"
+)
+
+// LeastLoadStrategy picks the candidates with the most stable RTT, based on the recent probes
+// of the observatory. Candidates are ranked by average RTT plus its deviation.
+type LeastLoadStrategy struct {
+	settings    *StrategyLeastLoadConfig
+	ctx         context.Context
+	observatory extension.Observatory
+}
+
+type leastLoadNode struct {
+	tag   string
+	score int64
+}
+
+func (l *LeastLoadStrategy) InjectContext(ctx context.Context) {
+	l.ctx = ctx
+}
+
+func (l *LeastLoadStrategy) PickOutbound(tags []string) string {
+	if l.observatory == nil {
+		if o := core.MustFromContext(l.ctx).GetFeature(extension.ObservatoryType()); o != nil {
+			l.observatory = o.(extension.Observatory)
+		}
+	}
+	if l.observatory == nil {
+		// The fallback of the balancer is used instead.
+		newError("least load strategy requires observatory").AtWarning().WriteToLog()
+		return ""
+	}
+
+	observeReport, err := l.observatory.GetObservation(l.ctx)
+	if err != nil {
+		newError("cannot get observe report").Base(err).WriteToLog()
+		return ""
+	}
+	result, ok := observeReport.(*observatory.ObservationResult)
+	if !ok {
+		// No way to understand observeReport
+		return ""
+	}
+
+	nodes := l.selectNodes(outboundList(tags), result.Status)
+	if len(nodes) == 0 {
+		return ""
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].score < nodes[j].score
+	})
+
+	expected := int(l.settings.GetExpected())
+	if expected <= 0 {
+		expected = 1
+	}
+	if expected > len(nodes) {
+		expected = len(nodes)
+	}
+	return nodes[dice.Roll(expected)].tag
+}
+
+func (l *LeastLoadStrategy) selectNodes(candidates outboundList, status []*observatory.OutboundStatus) []leastLoadNode {
+	maxRTT := l.settings.GetMaxRtt()
+	tolerance := l.settings.GetTolerance()
+
+	var nodes []leastLoadNode
+	for _, s := range status {
+		if !s.Alive || !candidates.contains(s.OutboundTag) {
+			continue
+		}
+		average, deviation := s.Delay, int64(0)
+		if health := s.HealthPing; health != nil && health.All > health.Fail {
+			average, deviation = health.Average, health.Deviation
+			if tolerance > 0 && float32(health.Fail)/float32(health.All) > tolerance {
+				continue
+			}
+		}
+		if maxRTT > 0 && average > maxRTT {
+			continue
+		}
+		nodes = append(nodes, leastLoadNode{
+			tag:   s.OutboundTag,
+			score: average + deviation,
+		})
+	}
+	return nodes
+}
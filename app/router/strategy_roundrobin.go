@@ -0,0 +1,30 @@
+package router
+
+import (
+	"sort"
+	"sync"
+)
+
+// RoundRobinStrategy picks the candidates in turn, ordered by tag.
+type RoundRobinStrategy struct {
+	access sync.Mutex
+	next   int
+}
+
+func (s *RoundRobinStrategy) PickOutbound(tags []string) string {
+	n := len(tags)
+	if n == 0 {
+		return ""
+	}
+
+	sorted := make([]string, n)
+	copy(sorted, tags)
+	sort.Strings(sorted)
+
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	tag := sorted[s.next%n]
+	s.next = (s.next + 1) % n
+	return tag
+}
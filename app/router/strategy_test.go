@@ -0,0 +1,127 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/app/observatory"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/extension"
+	"github.com/xtls/xray-core/features/outbound"
+)
+
+type staticObservatory struct {
+	extension.Observatory
+	status []*observatory.OutboundStatus
+}
+
+func (o *staticObservatory) GetObservation(ctx context.Context) (proto.Message, error) {
+	return &observatory.ObservationResult{Status: o.status}, nil
+}
+
+type staticHandlerSelector struct {
+	outbound.Manager
+	tags []string
+}
+
+func (s *staticHandlerSelector) Select([]string) []string {
+	return s.tags
+}
+
+func TestRoundRobinStrategy(t *testing.T) {
+	s := &RoundRobinStrategy{}
+	tags := []string{"c", "a", "b"}
+	for i, expected := range []string{"a", "b", "c", "a"} {
+		if tag := s.PickOutbound(tags); tag != expected {
+			t.Error("pick ", i, ": expect ", expected, ", but actually ", tag)
+		}
+	}
+}
+
+func TestWeightedStrategy(t *testing.T) {
+	s := &WeightedStrategy{
+		settings: &StrategyWeightedConfig{
+			Weights: []*StrategyWeight{
+				{Selector: "never", Weight: 0},
+				{Selector: "often", Weight: 9},
+			},
+		},
+	}
+	tags := []string{"never-a", "often-a", "sometimes-a"}
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		counts[s.PickOutbound(tags)]++
+	}
+	if counts["never-a"] != 0 {
+		t.Error("outbound with weight 0 is picked")
+	}
+	if counts["often-a"] < counts["sometimes-a"]*3 {
+		t.Error("unexpected distribution: ", counts)
+	}
+	if tag := s.PickOutbound([]string{"never-b"}); tag != "" {
+		t.Error("expect no outbound, but actually ", tag)
+	}
+}
+
+func TestLeastLoadStrategy(t *testing.T) {
+	s := &LeastLoadStrategy{
+		settings: &StrategyLeastLoadConfig{
+			MaxRtt:    1000,
+			Tolerance: 0.2,
+		},
+		observatory: &staticObservatory{
+			status: []*observatory.OutboundStatus{
+				{OutboundTag: "dead", Alive: false},
+				{OutboundTag: "slow", Alive: true, Delay: 50, HealthPing: &observatory.HealthPingMeasurementResult{All: 10, Average: 1500}},
+				{OutboundTag: "unstable", Alive: true, Delay: 50, HealthPing: &observatory.HealthPingMeasurementResult{All: 10, Average: 100, Deviation: 300}},
+				{OutboundTag: "failing", Alive: true, Delay: 50, HealthPing: &observatory.HealthPingMeasurementResult{All: 10, Fail: 5, Average: 50}},
+				{OutboundTag: "stable", Alive: true, Delay: 50, HealthPing: &observatory.HealthPingMeasurementResult{All: 10, Fail: 1, Average: 150, Deviation: 10}},
+				{OutboundTag: "other", Alive: true, Delay: 10},
+			},
+		},
+	}
+
+	tags := []string{"dead", "slow", "unstable", "failing", "stable"}
+	if tag := s.PickOutbound(tags); tag != "stable" {
+		t.Error("expect 'stable', but actually ", tag)
+	}
+	if tag := s.PickOutbound([]string{"dead", "slow"}); tag != "" {
+		t.Error("expect no outbound, but actually ", tag)
+	}
+}
+
+func TestBalancerFallback(t *testing.T) {
+	b := &Balancer{
+		strategy:    &LeastLoadStrategy{observatory: &staticObservatory{}},
+		ohm:         &staticHandlerSelector{tags: []string{"a", "b"}},
+		fallbackTag: "direct",
+	}
+	tag, err := b.PickOutbound()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag != "direct" {
+		t.Error("expect fallback 'direct', but actually ", tag)
+	}
+}
+
+func TestBalancerFallbackWithoutObservatory(t *testing.T) {
+	instance, err := core.New(&core.Config{})
+	common.Must(err)
+	strategy := &LeastLoadStrategy{}
+	strategy.InjectContext(context.WithValue(context.Background(), core.XrayKey(1), instance))
+	b := &Balancer{
+		strategy:    strategy,
+		ohm:         &staticHandlerSelector{tags: []string{"a", "b"}},
+		fallbackTag: "direct",
+	}
+	tag, err := b.PickOutbound()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag != "direct" {
+		t.Error("expect fallback 'direct', but actually ", tag)
+	}
+}
@@ -0,0 +1,42 @@
+package router
+
+import (
+	"strings"
+
+	"github.com/xtls/xray-core/common/dice"
+)
+
+// WeightedStrategy picks a candidate at random, in proportion to its weight.
+type WeightedStrategy struct {
+	settings *StrategyWeightedConfig
+}
+
+func (s *WeightedStrategy) weightOf(tag string) uint32 {
+	for _, w := range s.settings.GetWeights() {
+		if strings.HasPrefix(tag, w.Selector) {
+			return w.Weight
+		}
+	}
+	return 1
+}
+
+func (s *WeightedStrategy) PickOutbound(tags []string) string {
+	weights := make([]uint32, len(tags))
+	var total uint64
+	for i, tag := range tags {
+		weights[i] = s.weightOf(tag)
+		total += uint64(weights[i])
+	}
+	if total == 0 {
+		return ""
+	}
+
+	roll := dice.RollUint64() % total
+	for i, w := range weights {
+		if roll < uint64(w) {
+			return tags[i]
+		}
+		roll -= uint64(w)
+	}
+	return ""
+}
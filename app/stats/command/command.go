@@ -5,6 +5,7 @@ package command
 import (
 	"context"
 	"runtime"
+	"sort"
 	"time"
 
 	"github.com/xtls/xray-core/app/stats"
@@ -101,6 +102,114 @@ func (s *statsServer) GetSysStats(ctx context.Context, request *SysStatsRequest)
 	return response, nil
 }
 
+func (s *statsServer) ResetUserQuota(ctx context.Context, request *ResetUserQuotaRequest) (*ResetUserQuotaResponse, error) {
+	if len(request.Email) == 0 {
+		return nil, newError("user email is empty")
+	}
+
+	response := &ResetUserQuotaResponse{}
+	for _, direction := range []string{"uplink", "downlink"} {
+		name := "user>>>" + request.Email + ">>>traffic>>>" + direction
+		if c := s.stats.GetCounter(name); c != nil {
+			response.Stat = append(response.Stat, &Stat{
+				Name:  name,
+				Value: c.Set(0),
+			})
+		}
+	}
+	return response, nil
+}
+
+func (s *statsServer) ListConnections(ctx context.Context, request *ListConnectionsRequest) (*ListConnectionsResponse, error) {
+	tracker, ok := s.stats.(feature_stats.ConnectionTracker)
+	if !ok {
+		return nil, newError("stats manager doesn't track connections")
+	}
+
+	response := &ListConnectionsResponse{}
+	tracker.VisitConnections(func(conn *feature_stats.Connection) bool {
+		if matchConnection(conn, request.InboundTag, request.Email) {
+			response.Connections = append(response.Connections, toProtoConnection(conn))
+		}
+		return true
+	})
+	sortConnections(response.Connections)
+	return response, nil
+}
+
+func (s *statsServer) CloseConnections(ctx context.Context, request *CloseConnectionsRequest) (*CloseConnectionsResponse, error) {
+	tracker, ok := s.stats.(feature_stats.ConnectionTracker)
+	if !ok {
+		return nil, newError("stats manager doesn't track connections")
+	}
+	if len(request.Ids) == 0 && len(request.InboundTag) == 0 && len(request.Email) == 0 {
+		return nil, newError("no connection specified")
+	}
+
+	ids := make(map[uint64]bool, len(request.Ids))
+	for _, id := range request.Ids {
+		ids[id] = true
+	}
+
+	response := &CloseConnectionsResponse{}
+	tracker.VisitConnections(func(conn *feature_stats.Connection) bool {
+		if len(ids) > 0 && !ids[conn.ID] {
+			return true
+		}
+		if !matchConnection(conn, request.InboundTag, request.Email) {
+			return true
+		}
+		// The connection may have ended after being visited.
+		if err := tracker.CloseConnection(conn.ID); err == nil {
+			response.Connections = append(response.Connections, toProtoConnection(conn))
+		}
+		return true
+	})
+	sortConnections(response.Connections)
+	return response, nil
+}
+
+func matchConnection(conn *feature_stats.Connection, inboundTag string, email string) bool {
+	if len(inboundTag) > 0 && conn.InboundTag != inboundTag {
+		return false
+	}
+	if len(email) > 0 && conn.Email != email {
+		return false
+	}
+	return true
+}
+
+func toProtoConnection(conn *feature_stats.Connection) *Connection {
+	c := &Connection{
+		Id:          conn.ID,
+		SessionId:   conn.SessionID,
+		InboundTag:  conn.InboundTag,
+		Email:       conn.Email,
+		Domain:      conn.Domain,
+		OutboundTag: conn.OutboundTag,
+		StartTime:   conn.StartTime.Unix(),
+	}
+	if conn.Source.IsValid() {
+		c.Source = conn.Source.String()
+	}
+	if conn.Target.IsValid() {
+		c.Destination = conn.Target.String()
+	}
+	if conn.Uplink != nil {
+		c.Uplink = conn.Uplink.Value()
+	}
+	if conn.Downlink != nil {
+		c.Downlink = conn.Downlink.Value()
+	}
+	return c
+}
+
+func sortConnections(conns []*Connection) {
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].Id < conns[j].Id
+	})
+}
+
 func (s *statsServer) mustEmbedUnimplementedStatsServiceServer() {}
 
 type service struct {
@@ -3,12 +3,15 @@ package command_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/xtls/xray-core/app/stats"
 	. "github.com/xtls/xray-core/app/stats/command"
 	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	feature_stats "github.com/xtls/xray-core/features/stats"
 )
 
 func TestGetStats(t *testing.T) {
@@ -89,3 +92,82 @@ func TestQueryStats(t *testing.T) {
 		t.Error(r)
 	}
 }
+
+func TestResetUserQuota(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	uplink, err := m.RegisterCounter("user>>>test>>>traffic>>>uplink")
+	common.Must(err)
+	uplink.Set(10)
+	downlink, err := m.RegisterCounter("user>>>test>>>traffic>>>downlink")
+	common.Must(err)
+	downlink.Set(20)
+
+	s := NewStatsServer(m)
+	resp, err := s.ResetUserQuota(context.Background(), &ResetUserQuotaRequest{Email: "test"})
+	common.Must(err)
+	if r := cmp.Diff(resp.Stat, []*Stat{
+		{Name: "user>>>test>>>traffic>>>uplink", Value: 10},
+		{Name: "user>>>test>>>traffic>>>downlink", Value: 20},
+	}, cmpopts.IgnoreUnexported(Stat{})); r != "" {
+		t.Error(r)
+	}
+	if uplink.Value() != 0 || downlink.Value() != 0 {
+		t.Error("expect counters reset, but got ", uplink.Value(), " ", downlink.Value())
+	}
+
+	if _, err := s.ResetUserQuota(context.Background(), &ResetUserQuotaRequest{}); err == nil {
+		t.Error("expect error for empty email")
+	}
+}
+
+func TestConnections(t *testing.T) {
+	m, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+
+	start := time.Unix(1000, 0)
+	closed := make(map[string]bool)
+	track := func(email string) func() {
+		uplink := new(stats.Counter)
+		uplink.Set(int64(len(email)))
+		return m.TrackConnection(&feature_stats.Connection{
+			InboundTag: "in",
+			Email:      email,
+			Source:     net.TCPDestination(net.LocalHostIP, 1080),
+			Target:     net.TCPDestination(net.DomainAddress("example.com"), 443),
+			Uplink:     uplink,
+			Downlink:   new(stats.Counter),
+			StartTime:  start,
+		}, func() {
+			closed[email] = true
+		})
+	}
+	track("a@example.com")
+	untrack := track("b@example.com")
+	track("c@example.com")
+	untrack()
+
+	s := NewStatsServer(m)
+	resp, err := s.ListConnections(context.Background(), &ListConnectionsRequest{InboundTag: "in"})
+	common.Must(err)
+	if r := cmp.Diff(resp.Connections, []*Connection{
+		{Id: 1, InboundTag: "in", Email: "a@example.com", Source: "tcp:127.0.0.1:1080", Destination: "tcp:example.com:443", Uplink: 13, StartTime: 1000},
+		{Id: 3, InboundTag: "in", Email: "c@example.com", Source: "tcp:127.0.0.1:1080", Destination: "tcp:example.com:443", Uplink: 13, StartTime: 1000},
+	}, cmpopts.IgnoreUnexported(Connection{})); r != "" {
+		t.Error(r)
+	}
+
+	closeResp, err := s.CloseConnections(context.Background(), &CloseConnectionsRequest{Email: "c@example.com"})
+	common.Must(err)
+	if len(closeResp.Connections) != 1 || closeResp.Connections[0].Id != 3 {
+		t.Error("expect connection 3 closed, but got ", closeResp.Connections)
+	}
+	if closed["a@example.com"] || !closed["c@example.com"] {
+		t.Error("unexpected closed connections: ", closed)
+	}
+
+	if _, err := s.CloseConnections(context.Background(), &CloseConnectionsRequest{}); err == nil {
+		t.Error("expect error for closing connections without filter")
+	}
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"github.com/xtls/xray-core/features/stats"
+)
+
+type trackedConnection struct {
+	conn  *stats.Connection
+	close func()
+}
+
+// TrackConnection implements stats.ConnectionTracker.
+func (m *Manager) TrackConnection(conn *stats.Connection, close func()) func() {
+	m.connAccess.Lock()
+	defer m.connAccess.Unlock()
+
+	m.lastConnID++
+	conn.ID = m.lastConnID
+	m.connections[conn.ID] = &trackedConnection{
+		conn:  conn,
+		close: close,
+	}
+	return func() {
+		m.connAccess.Lock()
+		defer m.connAccess.Unlock()
+
+		delete(m.connections, conn.ID)
+	}
+}
+
+// VisitConnections implements stats.ConnectionTracker.
+func (m *Manager) VisitConnections(visitor func(*stats.Connection) bool) {
+	m.connAccess.RLock()
+	conns := make([]*stats.Connection, 0, len(m.connections))
+	for _, c := range m.connections {
+		conns = append(conns, c.conn)
+	}
+	m.connAccess.RUnlock()
+
+	// Visitor is called without lock, so that it can close the connections.
+	for _, conn := range conns {
+		if !visitor(conn) {
+			break
+		}
+	}
+}
+
+// CloseConnection implements stats.ConnectionTracker.
+func (m *Manager) CloseConnection(id uint64) error {
+	m.connAccess.RLock()
+	c, found := m.connections[id]
+	m.connAccess.RUnlock()
+
+	if !found {
+		return newError("connection ", id, " not found")
+	}
+	newError("closing connection ", id).AtInfo().WriteToLog()
+	c.close()
+	return nil
+}
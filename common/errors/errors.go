@@ -142,8 +142,9 @@ func (err *Error) WriteToLog(opts ...ExportOption) {
 	}
 
 	log.Record(&log.GeneralMessage{
-		Severity: GetSeverity(err),
-		Content:  err,
+		Severity:  GetSeverity(err),
+		Content:   err,
+		SessionID: holder.SessionID,
 	})
 }
 
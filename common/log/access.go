@@ -3,6 +3,7 @@ package log
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/xtls/xray-core/common/serial"
 )
@@ -18,6 +19,8 @@ type AccessStatus string
 const (
 	AccessAccepted = AccessStatus("accepted")
 	AccessRejected = AccessStatus("rejected")
+	// AccessClosed is recorded when an accepted session ends.
+	AccessClosed = AccessStatus("closed")
 )
 
 type AccessMessage struct {
@@ -27,6 +30,17 @@ type AccessMessage struct {
 	Reason interface{}
 	Email  string
 	Detour string
+
+	SessionID   uint32
+	InboundTag  string
+	OutboundTag string
+	// Protocol and Domain are the sniffing result of the session.
+	Protocol string
+	Domain   string
+	// Uplink, Downlink and Duration are only set in AccessClosed messages.
+	Uplink   int64
+	Downlink int64
+	Duration time.Duration
 }
 
 func (m *AccessMessage) String() string {
@@ -20,6 +20,8 @@ type Handler interface {
 type GeneralMessage struct {
 	Severity Severity
 	Content  interface{}
+	// SessionID is the ID of the session that the message belongs to, or 0 if none.
+	SessionID uint32
 }
 
 // String implements Message.
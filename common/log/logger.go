@@ -96,8 +96,12 @@ func (w *consoleLogWriter) Close() error {
 	return nil
 }
 
+func (w *consoleLogWriter) setFlags(flag int) {
+	w.logger.SetFlags(flag)
+}
+
 type fileLogWriter struct {
-	file   *os.File
+	file   io.Closer
 	logger *log.Logger
 }
 
@@ -110,6 +114,10 @@ func (w *fileLogWriter) Close() error {
 	return w.file.Close()
 }
 
+func (w *fileLogWriter) setFlags(flag int) {
+	w.logger.SetFlags(flag)
+}
+
 // CreateStdoutLogWriter returns a LogWriterCreator that creates LogWriter for stdout.
 func CreateStdoutLogWriter() WriterCreator {
 	return func() Writer {
@@ -147,6 +155,18 @@ func CreateFileLogWriter(path string) (WriterCreator, error) {
 	}, nil
 }
 
+// CreatePlainLogWriter returns a LogWriterCreator that creates the same LogWriter as creator,
+// but without the date and time prefix of each line.
+func CreatePlainLogWriter(creator WriterCreator) WriterCreator {
+	return func() Writer {
+		writer := creator()
+		if w, ok := writer.(interface{ setFlags(int) }); ok {
+			w.setFlags(0)
+		}
+		return writer
+	}
+}
+
 func init() {
 	RegisterHandler(NewLogger(CreateStdoutLogWriter()))
 }
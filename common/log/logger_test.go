@@ -2,6 +2,7 @@ package log_test
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -36,3 +37,76 @@ func TestFileLogger(t *testing.T) {
 		t.Fatal("Expect log text contains 'Test Log', but actually: ", string(b))
 	}
 }
+
+func TestRotatingFileLogger(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "access.log")
+
+	creator, err := CreateRotatingFileLogWriter(path, RotateOptions{
+		MaxSize:    100,
+		MaxBackups: 2,
+		Compress:   true,
+	})
+	common.Must(err)
+
+	writer := creator()
+	for i := 0; i < 5; i++ {
+		common.Must(writer.Write(strings.Repeat("a", 60)))
+	}
+	common.Must(writer.Close())
+
+	var backups []string
+	for i := 0; i < 20; i++ {
+		time.Sleep(100 * time.Millisecond)
+		backups, err = filepath.Glob(path + ".*")
+		common.Must(err)
+		if len(backups) == 2 && strings.HasSuffix(backups[0], ".gz") && strings.HasSuffix(backups[1], ".gz") {
+			break
+		}
+	}
+	if len(backups) != 2 {
+		t.Fatal("expect 2 rotated files, but actually ", backups)
+	}
+	for _, backup := range backups {
+		if !strings.HasSuffix(backup, ".gz") {
+			t.Error("expect compressed file, but actually ", backup)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	common.Must(err)
+	if strings.Count(string(b), strings.Repeat("a", 60)) != 1 {
+		t.Error("expect one line in current log file, but actually: ", string(b))
+	}
+}
+
+func TestRotatingFileLoggerSharedPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "xray.log")
+
+	options := RotateOptions{
+		MaxSize: 100,
+	}
+	accessCreator, err := CreateRotatingFileLogWriter(path, options)
+	common.Must(err)
+	errorCreator, err := CreateRotatingFileLogWriter(path, options)
+	common.Must(err)
+
+	access := accessCreator()
+	errorWriter := errorCreator()
+	common.Must(access.Write(strings.Repeat("a", 60)))
+	common.Must(errorWriter.Write(strings.Repeat("b", 60)))
+	common.Must(access.Close())
+	common.Must(errorWriter.Close())
+
+	backups, err := filepath.Glob(path + ".*")
+	common.Must(err)
+	if len(backups) != 1 {
+		t.Fatal("expect 1 rotated file, but actually ", backups)
+	}
+	b, err := os.ReadFile(path)
+	common.Must(err)
+	if strings.Contains(string(b), "a") || !strings.Contains(string(b), strings.Repeat("b", 60)) {
+		t.Error("expect only the second line in current log file, but actually: ", string(b))
+	}
+}
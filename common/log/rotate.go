@@ -0,0 +1,325 @@
+package log
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotateOptions are the options of rotating log files.
+type RotateOptions struct {
+	// MaxSize is the size in bytes at which the log file is rotated. Disabled if 0.
+	MaxSize int64
+	// Interval is the period of the log file, aligned to local time. Disabled if 0.
+	Interval time.Duration
+	// MaxBackups is the number of rotated files to keep. All files are kept if 0.
+	MaxBackups int
+	// Compress enables gzip of rotated files.
+	Compress bool
+}
+
+const rotateTimeFormat = "20060102-150405"
+
+// reopenRetryInterval is the interval to retry opening the log file, after it fails to be reopened
+// on rotation.
+const reopenRetryInterval = 5 * time.Second
+
+var errNotOpened = errors.New("log file is not opened")
+
+// fileRotator rotates a log file.
+type fileRotator struct {
+	path    string
+	options RotateOptions
+
+	// cleanup serializes compressing and removing rotated files.
+	cleanup sync.Mutex
+}
+
+// periodStart returns the start of the rotation period that t is in.
+func (r *fileRotator) periodStart(t time.Time) time.Time {
+	_, offset := t.Zone()
+	shift := time.Duration(offset) * time.Second
+	return t.Add(shift).Truncate(r.options.Interval).Add(-shift)
+}
+
+// rotate renames the log file, and compresses and removes rotated files in background.
+func (r *fileRotator) rotate(now time.Time) error {
+	name := r.path + "." + now.Format(rotateTimeFormat)
+	backup := name
+	for i := 1; fileExists(backup) || fileExists(backup+".gz"); i++ {
+		backup = name + "." + strconv.Itoa(i)
+	}
+	if err := os.Rename(r.path, backup); err != nil {
+		return err
+	}
+	go r.cleanupBackups(backup)
+	return nil
+}
+
+func (r *fileRotator) cleanupBackups(backup string) {
+	r.cleanup.Lock()
+	defer r.cleanup.Unlock()
+
+	if r.options.Compress {
+		if err := compressFile(backup); err != nil {
+			log.Printf("failed to compress log file %s: %v", backup, err)
+		}
+	}
+	if r.options.MaxBackups <= 0 {
+		return
+	}
+	matches, err := filepath.Glob(r.path + ".*")
+	if err != nil {
+		return
+	}
+	type backupFile struct {
+		path  string
+		time  string
+		index int
+	}
+	var backups []backupFile
+	for _, match := range matches {
+		// Rotated files are named as path.time[.index][.gz]
+		parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(match, r.path+"."), ".gz"), ".")
+		if _, err := time.Parse(rotateTimeFormat, parts[0]); err != nil || len(parts) > 2 {
+			continue
+		}
+		backup := backupFile{path: match, time: parts[0]}
+		if len(parts) == 2 {
+			if backup.index, err = strconv.Atoi(parts[1]); err != nil {
+				continue
+			}
+		}
+		backups = append(backups, backup)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].time != backups[j].time {
+			return backups[i].time < backups[j].time
+		}
+		return backups[i].index < backups[j].index
+	})
+	for len(backups) > r.options.MaxBackups {
+		os.Remove(backups[0].path)
+		backups = backups[1:]
+	}
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		zw.Close()
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// rotatingFile is a log file that is rotated by fileRotator before writing. Writers of the same path,
+// such as access and error logs on one file, share one rotatingFile, so that the file is rotated
+// once by its total size.
+type rotatingFile struct {
+	rotator *fileRotator
+	key     string
+	// refs is the number of writers of the file, guarded by sharedFilesAccess.
+	refs int
+
+	access sync.Mutex
+	file   *os.File
+	size   int64
+	// end is the end of the current rotation period.
+	end time.Time
+	// retry is the time to retry opening the file, if it failed to be reopened.
+	retry  time.Time
+	closed bool
+}
+
+var (
+	sharedFilesAccess sync.Mutex
+	sharedFiles       = make(map[string]*rotatingFile)
+)
+
+// openRotatingFile returns the rotating file of path, which is opened if it has no writers yet.
+// The rotate options of the writer opening the file are used until all its writers are closed.
+func openRotatingFile(path string, options RotateOptions) (*rotatingFile, error) {
+	key := path
+	if abs, err := filepath.Abs(path); err == nil {
+		key = abs
+	}
+
+	sharedFilesAccess.Lock()
+	defer sharedFilesAccess.Unlock()
+
+	if f, found := sharedFiles[key]; found {
+		if f.rotator.options != options {
+			log.Printf("log file %s is shared with different rotate options, using the ones of its first writer", path)
+		}
+		f.refs++
+		return f, nil
+	}
+	f := &rotatingFile{
+		rotator: &fileRotator{
+			path:    path,
+			options: options,
+		},
+		key:  key,
+		refs: 1,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	sharedFiles[key] = f
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.rotator.path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	if interval := f.rotator.options.Interval; interval > 0 {
+		// The file may be written in a previous period.
+		f.end = f.rotator.periodStart(info.ModTime()).Add(interval)
+		if info.Size() == 0 {
+			f.end = f.rotator.periodStart(time.Now()).Add(interval)
+		}
+	}
+	return nil
+}
+
+func (f *rotatingFile) shouldRotate(now time.Time, n int) bool {
+	if f.size == 0 {
+		return false
+	}
+	options := f.rotator.options
+	if options.MaxSize > 0 && f.size+int64(n) > options.MaxSize {
+		return true
+	}
+	return options.Interval > 0 && !now.Before(f.end)
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.access.Lock()
+	defer f.access.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	now := time.Now()
+	if f.file == nil {
+		if now.Before(f.retry) {
+			return 0, errNotOpened
+		}
+		if err := f.open(); err != nil {
+			f.openFailed(now, err)
+			return 0, err
+		}
+	}
+	if f.shouldRotate(now, len(p)) {
+		if err := f.reopen(now); err != nil {
+			f.openFailed(now, err)
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) reopen(now time.Time) error {
+	f.file.Close()
+	f.file = nil
+	if err := f.rotator.rotate(now); err != nil {
+		// Keep writing to the current file.
+		log.Printf("failed to rotate log file %s: %v", f.rotator.path, err)
+	}
+	return f.open()
+}
+
+// openFailed reports the failure of opening the file, which is retried after reopenRetryInterval.
+func (f *rotatingFile) openFailed(now time.Time, err error) {
+	log.Printf("failed to open log file %s, retrying in %v: %v", f.rotator.path, reopenRetryInterval, err)
+	f.retry = now.Add(reopenRetryInterval)
+}
+
+// Close closes the file after all its writers are closed.
+func (f *rotatingFile) Close() error {
+	sharedFilesAccess.Lock()
+	f.refs--
+	if f.refs > 0 {
+		sharedFilesAccess.Unlock()
+		return nil
+	}
+	delete(sharedFiles, f.key)
+	sharedFilesAccess.Unlock()
+
+	f.access.Lock()
+	defer f.access.Unlock()
+
+	f.closed = true
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// CreateRotatingFileLogWriter returns a LogWriterCreator that creates LogWriter for the given file,
+// which is rotated by the given options.
+func CreateRotatingFileLogWriter(path string, options RotateOptions) (WriterCreator, error) {
+	file, err := openRotatingFile(path, options)
+	if err != nil {
+		return nil, err
+	}
+	file.Close()
+	return func() Writer {
+		file, err := openRotatingFile(path, options)
+		if err != nil {
+			return nil
+		}
+		return &fileLogWriter{
+			file:   file,
+			logger: log.New(file, "", log.Ldate|log.Ltime),
+		}
+	}, nil
+}
@@ -0,0 +1,26 @@
+// Package process finds the local processes which own network connections.
+package process
+
+import (
+	"path/filepath"
+)
+
+//go:generate go run github.com/xtls/xray-core/common/errors/errorgen
+
+// Info is the information of a local process.
+type Info struct {
+	// PID is the process ID, or 0 if only the owner of the socket is found.
+	PID int
+	// UID is the user ID owning the socket.
+	UID uint32
+	// Path is the path of the executable, which may be empty if it is not accessible.
+	Path string
+}
+
+// Name returns the file name of the executable.
+func (i *Info) Name() string {
+	if len(i.Path) == 0 {
+		return ""
+	}
+	return filepath.Base(i.Path)
+}
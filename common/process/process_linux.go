@@ -0,0 +1,283 @@
+//go:build linux
+// +build linux
+
+package process
+
+import (
+	"bufio"
+	"encoding/hex"
+	gonet "net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/xtls/xray-core/common/net"
+)
+
+// FindProcess returns the local process owning the socket, whose local address is src. The socket
+// is found in the socket tables under /proc/net, and its process by scanning the file descriptors
+// of processes, so only processes of the same user are found unless running as root.
+func FindProcess(src net.Destination) (*Info, error) {
+	if !src.IsValid() || !src.Address.Family().IsIP() {
+		return nil, newError("invalid source ", src)
+	}
+	if !isLocalIP(src.Address.IP()) {
+		return nil, newError("source ", src, " is not local")
+	}
+
+	var tables []string
+	switch src.Network {
+	case net.Network_TCP:
+		tables = []string{"/proc/net/tcp", "/proc/net/tcp6"}
+	case net.Network_UDP:
+		tables = []string{"/proc/net/udp", "/proc/net/udp6"}
+	default:
+		return nil, newError("unsupported network ", src.Network)
+	}
+
+	for _, table := range tables {
+		uid, inode, err := findSocket(table, src.Address.IP(), src.Port, src.Network == net.Network_UDP)
+		if err != nil {
+			return nil, err
+		}
+		if inode == 0 {
+			continue
+		}
+
+		info := &Info{UID: uid}
+		if pid := findPID(uid, inode); pid > 0 {
+			info.PID = pid
+			info.Path, _ = os.Readlink("/proc/" + strconv.Itoa(pid) + "/exe")
+		}
+		return info, nil
+	}
+	return nil, newError("socket of ", src, " is not found")
+}
+
+// isLocalIP returns whether ip is an address of this host. Sources of other addresses are remote
+// clients, which may have the same port as a local socket by chance.
+func isLocalIP(ip net.IP) bool {
+	if ip.IsLoopback() {
+		return true
+	}
+	addrs, err := gonet.InterfaceAddrs()
+	if err != nil {
+		return false
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*gonet.IPNet); ok && ipNet.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// findSocket returns the owner and inode of the socket bound to ip and port in the table, or
+// inode 0 if not found. If unspecified is true, sockets bound to the unspecified address are
+// matched if there is no exact match, which is common for UDP. TCP connections always have their
+// own sockets, so a match of the unspecified address is a listener, but not the source.
+func findSocket(table string, ip net.IP, port net.Port, unspecified bool) (uint32, uint64, error) {
+	file, err := os.Open(table)
+	if os.IsNotExist(err) {
+		// Such as the IPv6 tables if IPv6 is disabled.
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, newError("failed to read ", table).Base(err)
+	}
+	defer file.Close()
+
+	var wildcardUID uint32
+	var wildcardInode uint64
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // Skip the header.
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		localIP, localPort, ok := parseSocketAddress(fields[1])
+		if !ok || localPort != port {
+			continue
+		}
+		exact := localIP.Equal(ip)
+		if !exact && (!unspecified || !localIP.IsUnspecified()) {
+			continue
+		}
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			continue
+		}
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil || inode == 0 {
+			// Sockets in TIME_WAIT have no inode any more.
+			continue
+		}
+		if exact {
+			return uint32(uid), inode, nil
+		}
+		if wildcardInode == 0 {
+			wildcardUID, wildcardInode = uint32(uid), inode
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, newError("failed to read ", table).Base(err)
+	}
+	return wildcardUID, wildcardInode, nil
+}
+
+// parseSocketAddress parses an address in socket tables, such as "0100007F:0050". The IP is in
+// groups of 32-bit words in host byte order, which is assumed to be little endian.
+func parseSocketAddress(s string) (net.IP, net.Port, bool) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil, 0, false
+	}
+	ip, err := hex.DecodeString(s[:i])
+	if err != nil || (len(ip) != net.IPv4len && len(ip) != net.IPv6len) {
+		return nil, 0, false
+	}
+	for j := 0; j < len(ip); j += 4 {
+		ip[j], ip[j+1], ip[j+2], ip[j+3] = ip[j+3], ip[j+2], ip[j+1], ip[j]
+	}
+	port, err := strconv.ParseUint(s[i+1:], 16, 16)
+	if err != nil {
+		return nil, 0, false
+	}
+	return net.IP(ip), net.Port(port), true
+}
+
+const (
+	// pidCacheTTL is the time that the process of a socket is cached for.
+	pidCacheTTL = 10 * time.Second
+	// maxRecentPIDs is the number of processes found lately, which are scanned first.
+	maxRecentPIDs = 8
+)
+
+type cachedPID struct {
+	pid    int
+	expire time.Time
+}
+
+// pidFinder finds the processes of sockets. Scanning all processes is slow, so it caches the
+// processes of sockets seen in scans, and scans the processes found lately first, as a process
+// making a connection usually makes more.
+type pidFinder struct {
+	sync.Mutex
+	pids   map[uint64]cachedPID
+	recent []int
+}
+
+var finder = &pidFinder{
+	pids: make(map[uint64]cachedPID),
+}
+
+// findPID returns the ID of the process of user uid, which has the socket of inode open, or 0 if
+// not found.
+func findPID(uid uint32, inode uint64) int {
+	return finder.find(uid, inode)
+}
+
+func (f *pidFinder) find(uid uint32, inode uint64) int {
+	now := time.Now()
+	f.Lock()
+	cached, found := f.pids[inode]
+	recent := append([]int(nil), f.recent...)
+	f.Unlock()
+	if found && now.Before(cached.expire) {
+		return cached.pid
+	}
+
+	sockets := make(map[uint64]int)
+	pid := 0
+	for _, p := range recent {
+		if scanProcess(p, uid, inode, sockets) {
+			pid = p
+			break
+		}
+	}
+	if pid == 0 {
+		pid = scanProcesses(uid, inode, sockets)
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	for i, c := range f.pids {
+		if !now.Before(c.expire) {
+			delete(f.pids, i)
+		}
+	}
+	for i, p := range sockets {
+		f.pids[i] = cachedPID{pid: p, expire: now.Add(pidCacheTTL)}
+	}
+	if pid > 0 {
+		f.addRecent(pid)
+	}
+	return pid
+}
+
+func (f *pidFinder) addRecent(pid int) {
+	recent := make([]int, 0, maxRecentPIDs)
+	recent = append(recent, pid)
+	for _, p := range f.recent {
+		if p != pid && len(recent) < maxRecentPIDs {
+			recent = append(recent, p)
+		}
+	}
+	f.recent = recent
+}
+
+// scanProcesses scans processes of user uid for the socket of inode, and returns the ID of the
+// process having it open, or 0 if not found. Sockets seen in the scan are added into sockets.
+func scanProcesses(uid uint32, inode uint64, sockets map[uint64]int) int {
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0
+	}
+
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil || !proc.IsDir() {
+			continue
+		}
+		if scanProcess(pid, uid, inode, sockets) {
+			return pid
+		}
+	}
+	return 0
+}
+
+// scanProcess returns whether process pid has the socket of inode open. Sockets seen in the scan
+// are added into sockets.
+func scanProcess(pid int, uid uint32, inode uint64, sockets map[uint64]int) bool {
+	fdDir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
+	if info, err := os.Stat(fdDir); err != nil {
+		return false
+	} else if stat, ok := info.Sys().(*syscall.Stat_t); ok && stat.Uid != uid {
+		return false
+	}
+	fds, err := os.ReadDir(fdDir)
+	if err != nil {
+		return false
+	}
+	for _, fd := range fds {
+		link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+		if err != nil || !strings.HasPrefix(link, "socket:[") {
+			continue
+		}
+		i, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+		if err != nil {
+			continue
+		}
+		sockets[i] = pid
+		if i == inode {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+//go:build linux
+// +build linux
+
+package process_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	. "github.com/xtls/xray-core/common/process"
+)
+
+func TestFindProcess(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+
+	udpConn, err := net.ListenUDP("udp", &net.UDPAddr{})
+	common.Must(err)
+	defer udpConn.Close()
+
+	executable, err := os.Executable()
+	common.Must(err)
+
+	for _, src := range []net.Destination{
+		net.DestinationFromAddr(conn.LocalAddr()),
+		// Bound to the unspecified address.
+		net.UDPDestination(net.LocalHostIP, net.Port(udpConn.LocalAddr().(*net.UDPAddr).Port)),
+	} {
+		info, err := FindProcess(src)
+		common.Must(err)
+		if info.PID != os.Getpid() {
+			t.Error("expect PID ", os.Getpid(), " of ", src, ", but got ", info.PID)
+		}
+		if info.UID != uint32(os.Getuid()) {
+			t.Error("expect UID ", os.Getuid(), " of ", src, ", but got ", info.UID)
+		}
+		if info.Path != executable {
+			t.Error("expect path ", executable, " of ", src, ", but got ", info.Path)
+		}
+	}
+
+	if _, err := FindProcess(net.TCPDestination(net.LocalHostIP, 1)); err == nil {
+		t.Error("expect error of unknown socket")
+	}
+
+	// A TCP listener on the unspecified address is not the source of connections to it.
+	wildcard, err := net.Listen("tcp", ":0")
+	common.Must(err)
+	defer wildcard.Close()
+	port := net.Port(wildcard.Addr().(*net.TCPAddr).Port)
+	if _, err := FindProcess(net.TCPDestination(net.LocalHostIP, port)); err == nil {
+		t.Error("expect error of TCP source matching a listener")
+	}
+	if _, err := FindProcess(net.UDPDestination(net.ParseAddress("203.0.113.1"), net.Port(udpConn.LocalAddr().(*net.UDPAddr).Port))); err == nil {
+		t.Error("expect error of remote source")
+	}
+}
+
+func BenchmarkFindProcess(b *testing.B) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+
+	src := net.DestinationFromAddr(conn.LocalAddr())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := FindProcess(src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
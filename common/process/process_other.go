@@ -0,0 +1,15 @@
+//go:build !linux
+// +build !linux
+
+package process
+
+import (
+	"runtime"
+
+	"github.com/xtls/xray-core/common/net"
+)
+
+// FindProcess returns the local process owning the socket, whose local address is src.
+func FindProcess(src net.Destination) (*Info, error) {
+	return nil, newError("finding process is not supported on ", runtime.GOOS)
+}
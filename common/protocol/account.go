@@ -1,5 +1,7 @@
 package protocol
 
+import "github.com/golang/protobuf/proto"
+
 // Account is a user identity used for authentication.
 type Account interface {
 	Equals(Account) bool
@@ -9,3 +11,8 @@ type Account interface {
 type AsAccount interface {
 	AsAccount() (Account, error)
 }
+
+// HasProto is an account that can be converted back into its proto form.
+type HasProto interface {
+	ToProto() proto.Message
+}
@@ -1,5 +1,7 @@
 package protocol
 
+import "github.com/xtls/xray-core/common/serial"
+
 func (u *User) GetTypedAccount() (Account, error) {
 	if u.GetAccount() == nil {
 		return nil, newError("Account missing").AtWarning()
@@ -27,6 +29,8 @@ func (u *User) ToMemoryUser() (*MemoryUser, error) {
 		Account: account,
 		Email:   u.Email,
 		Level:   u.Level,
+		Quota:   u.Quota,
+		Expiry:  u.Expiry,
 	}, nil
 }
 
@@ -36,4 +40,22 @@ type MemoryUser struct {
 	Account Account
 	Email   string
 	Level   uint32
+	// Traffic quota in bytes. 0 for unlimited.
+	Quota uint64
+	// Unix time in seconds after which the user is rejected. 0 for never.
+	Expiry int64
+}
+
+// ToProtoUser converts this MemoryUser back to User. The account is left empty if it doesn't implement HasProto.
+func (u *MemoryUser) ToProtoUser() *User {
+	user := &User{
+		Email:  u.Email,
+		Level:  u.Level,
+		Quota:  u.Quota,
+		Expiry: u.Expiry,
+	}
+	if account, ok := u.Account.(HasProto); ok {
+		user.Account = serial.ToTypedMessage(account.ToProto())
+	}
+	return user
 }
@@ -12,10 +12,13 @@ func ToTypedMessage(message proto.Message) *TypedMessage {
 	if message == nil {
 		return nil
 	}
-	settings, _ := proto.Marshal(message)
+	// Marshal deterministically, so that equal messages always produce equal TypedMessages.
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	b.Marshal(message)
 	return &TypedMessage{
 		Type:  GetMessageType(message),
-		Value: settings,
+		Value: b.Bytes(),
 	}
 }
 
@@ -60,3 +60,91 @@ func BenchmarkMarchGroup(b *testing.B) {
 		_ = g.Match("0.example.com")
 	}
 }
+
+// benchmarkRules returns the domains of 16 rules, like routing rules with large geosite lists,
+// where rule i and i+8 share the same list.
+func benchmarkRules() [][]string {
+	rules := make([][]string, 16)
+	for i := range rules {
+		list := i % 8
+		for j := 0; j < 2048; j++ {
+			rules[i] = append(rules[i], strconv.Itoa(j)+".list"+strconv.Itoa(list)+".com")
+		}
+	}
+	return rules
+}
+
+// benchmarkInputs are domains matching the first rule, the last rule and no rules.
+var benchmarkInputs = []string{"www.1.list0.com", "www.1.list7.com", "www.example.com"}
+
+func BenchmarkMphMatcherGroupPerRule(b *testing.B) {
+	var groups []*MphMatcherGroup
+	for _, rule := range benchmarkRules() {
+		g := NewMphMatcherGroup()
+		for _, domain := range rule {
+			_, err := g.AddPattern(domain, Domain)
+			common.Must(err)
+		}
+		g.Build()
+		groups = append(groups, g)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, input := range benchmarkInputs {
+			for _, g := range groups {
+				if len(g.Match(input)) > 0 {
+					break
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkGroupMatcher(b *testing.B) {
+	g := NewGroupMatcher()
+	for i, rule := range benchmarkRules() {
+		for _, domain := range rule {
+			common.Must(g.Add(domain, Domain, uint32(i)))
+		}
+	}
+	g.Build()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, input := range benchmarkInputs {
+			_ = g.Match(input)
+		}
+	}
+}
+
+func BenchmarkMphMatcherGroupPerRuleBuild(b *testing.B) {
+	rules := benchmarkRules()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, rule := range rules {
+			g := NewMphMatcherGroup()
+			for _, domain := range rule {
+				_, err := g.AddPattern(domain, Domain)
+				common.Must(err)
+			}
+			g.Build()
+		}
+	}
+}
+
+func BenchmarkGroupMatcherBuild(b *testing.B) {
+	rules := benchmarkRules()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g := NewGroupMatcher()
+		for j, rule := range rules {
+			for _, domain := range rule {
+				common.Must(g.Add(domain, Domain, uint32(j)))
+			}
+		}
+		g.Build()
+	}
+}
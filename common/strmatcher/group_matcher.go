@@ -0,0 +1,189 @@
+package strmatcher
+
+import (
+	"encoding/binary"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type groupEntry struct {
+	m Matcher
+	// key is the type and pattern of m, which is the key of it in otherIndex.
+	key    string
+	groups []uint32
+}
+
+// GroupMatcher matches a string with the patterns of many groups at once, such as the domains of
+// all routing rules, and returns the groups with any pattern matching it. A pattern shared by
+// groups, such as a geosite list used in several rules, is only stored once.
+//
+// Full, domain and substr patterns are case-insensitive, and the input must be in lower case.
+type GroupMatcher struct {
+	full   map[string][]uint32
+	domain map[string][]uint32
+	// others are substr and regex patterns, which are matched one by one.
+	others []groupEntry
+	// otherIndex is the index of each substr and regex pattern in others, only used when adding.
+	otherIndex map[string]int
+}
+
+// NewGroupMatcher creates an empty GroupMatcher.
+func NewGroupMatcher() *GroupMatcher {
+	return &GroupMatcher{
+		full:       make(map[string][]uint32),
+		domain:     make(map[string][]uint32),
+		otherIndex: make(map[string]int),
+	}
+}
+
+// Add adds a pattern of type t into group.
+func (g *GroupMatcher) Add(pattern string, t Type, group uint32) error {
+	switch t {
+	case Full:
+		pattern = strings.ToLower(pattern)
+		g.full[pattern] = append(g.full[pattern], group)
+	case Domain:
+		pattern = strings.ToLower(pattern)
+		g.domain[pattern] = append(g.domain[pattern], group)
+	case Substr, Regex:
+		if t == Substr {
+			pattern = strings.ToLower(pattern)
+		}
+		key := string([]byte{byte(t)}) + pattern
+		if i, found := g.otherIndex[key]; found {
+			g.others[i].groups = append(g.others[i].groups, group)
+			return nil
+		}
+		var m Matcher
+		if t == Substr {
+			m = substrMatcher(pattern)
+		} else {
+			r, err := regexp.Compile(pattern)
+			if err != nil {
+				return err
+			}
+			m = &regexMatcher{pattern: r}
+		}
+		g.otherIndex[key] = len(g.others)
+		g.others = append(g.others, groupEntry{m: m, key: key, groups: []uint32{group}})
+	default:
+		panic("Unknown type")
+	}
+	return nil
+}
+
+// AddGroupMatcher adds the patterns of o, which may have been built, with their groups mapped by
+// group. Groups that group returns false for are dropped, so are patterns left without groups.
+func (g *GroupMatcher) AddGroupMatcher(o *GroupMatcher, group func(uint32) (uint32, bool)) {
+	mapGroups := func(groups []uint32) []uint32 {
+		var mapped []uint32
+		for _, old := range groups {
+			if n, ok := group(old); ok {
+				mapped = append(mapped, n)
+			}
+		}
+		return mapped
+	}
+	for pattern, groups := range o.full {
+		if mapped := mapGroups(groups); len(mapped) > 0 {
+			g.full[pattern] = append(g.full[pattern], mapped...)
+		}
+	}
+	for pattern, groups := range o.domain {
+		if mapped := mapGroups(groups); len(mapped) > 0 {
+			g.domain[pattern] = append(g.domain[pattern], mapped...)
+		}
+	}
+	for _, e := range o.others {
+		mapped := mapGroups(e.groups)
+		if len(mapped) == 0 {
+			continue
+		}
+		if i, found := g.otherIndex[e.key]; found {
+			g.others[i].groups = append(g.others[i].groups, mapped...)
+			continue
+		}
+		g.otherIndex[e.key] = len(g.others)
+		g.others = append(g.others, groupEntry{m: e.m, key: e.key, groups: mapped})
+	}
+}
+
+// Build sorts the groups of each pattern, and shares the same groups among patterns. It must be
+// called after all patterns are added, and before matching.
+func (g *GroupMatcher) Build() {
+	shared := make(map[string][]uint32)
+	share := func(groups []uint32) []uint32 {
+		sort.Slice(groups, func(i, j int) bool { return groups[i] < groups[j] })
+		groups = dedupGroups(groups)
+		key := make([]byte, 4*len(groups))
+		for i, group := range groups {
+			binary.BigEndian.PutUint32(key[4*i:], group)
+		}
+		if s, found := shared[string(key)]; found {
+			return s
+		}
+		groups = groups[:len(groups):len(groups)]
+		shared[string(key)] = groups
+		return groups
+	}
+	for pattern, groups := range g.full {
+		g.full[pattern] = share(groups)
+	}
+	for pattern, groups := range g.domain {
+		g.domain[pattern] = share(groups)
+	}
+	for i := range g.others {
+		g.others[i].groups = share(g.others[i].groups)
+	}
+	g.otherIndex = nil
+}
+
+func dedupGroups(groups []uint32) []uint32 {
+	n := 0
+	for i, group := range groups {
+		if i == 0 || group != groups[n-1] {
+			groups[n] = group
+			n++
+		}
+	}
+	return groups[:n]
+}
+
+// Match returns the groups in ascending order with any pattern matching input, which must not be
+// modified.
+func (g *GroupMatcher) Match(input string) []uint32 {
+	var buf [8][]uint32
+	matches := buf[:0]
+	if groups, found := g.full[input]; found {
+		matches = append(matches, groups)
+	}
+	for i := 0; ; {
+		if groups, found := g.domain[input[i:]]; found {
+			matches = append(matches, groups)
+		}
+		next := strings.IndexByte(input[i:], '.')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	for _, e := range g.others {
+		if e.m.Match(input) {
+			matches = append(matches, e.groups)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil
+	case 1:
+		return matches[0]
+	}
+	var result []uint32
+	for _, groups := range matches {
+		result = append(result, groups...)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return dedupGroups(result)
+}
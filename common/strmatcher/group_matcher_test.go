@@ -0,0 +1,95 @@
+package strmatcher_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	. "github.com/xtls/xray-core/common/strmatcher"
+)
+
+func TestGroupMatcher(t *testing.T) {
+	patterns := []struct {
+		pattern string
+		t       Type
+		group   uint32
+	}{
+		{"Example.com", Domain, 3},
+		{"www.example.com", Full, 1},
+		{"example.com", Domain, 5},
+		{"example.com", Domain, 5},
+		{"cdn", Substr, 2},
+		{"cdn", Substr, 4},
+		{"^img[0-9]+\\.", Regex, 4},
+		{"^img[0-9]+\\.", Regex, 0},
+		{"com", Domain, 7},
+	}
+	g := NewGroupMatcher()
+	for _, p := range patterns {
+		common.Must(g.Add(p.pattern, p.t, p.group))
+	}
+	g.Build()
+
+	cases := []struct {
+		input  string
+		output []uint32
+	}{
+		{"www.example.com", []uint32{1, 3, 5, 7}},
+		{"example.com", []uint32{3, 5, 7}},
+		{"myexample.com", []uint32{7}},
+		{"cdn.example.com", []uint32{2, 3, 4, 5, 7}},
+		{"img1.example.net", []uint32{0, 4}},
+		{"cdn.example.net", []uint32{2, 4}},
+		{"example.net", nil},
+		{"", nil},
+	}
+	for _, test := range cases {
+		if r := g.Match(test.input); !reflect.DeepEqual(r, test.output) {
+			t.Error("unexpected output: ", r, " for test case ", test)
+		}
+	}
+
+	if err := NewGroupMatcher().Add("(", Regex, 0); err == nil {
+		t.Error("expect error of invalid regex")
+	}
+}
+
+func TestGroupMatcherAddGroupMatcher(t *testing.T) {
+	o := NewGroupMatcher()
+	common.Must(o.Add("example.com", Domain, 0))
+	common.Must(o.Add("example.com", Domain, 2))
+	common.Must(o.Add("www.example.com", Full, 1))
+	common.Must(o.Add("cdn", Substr, 1))
+	common.Must(o.Add("^img[0-9]+\\.", Regex, 2))
+	o.Build()
+
+	// Drop group 1 and move group 2 to 1, like removing a rule.
+	g := NewGroupMatcher()
+	g.AddGroupMatcher(o, func(group uint32) (uint32, bool) {
+		switch group {
+		case 0:
+			return 0, true
+		case 2:
+			return 1, true
+		}
+		return 0, false
+	})
+	common.Must(g.Add("^img[0-9]+\\.", Regex, 2))
+	common.Must(g.Add("cdn", Substr, 2))
+	g.Build()
+
+	cases := []struct {
+		input  string
+		output []uint32
+	}{
+		{"www.example.com", []uint32{0, 1}},
+		{"cdn.example.net", []uint32{2}},
+		{"img1.example.net", []uint32{1, 2}},
+		{"example.net", nil},
+	}
+	for _, test := range cases {
+		if r := g.Match(test.input); !reflect.DeepEqual(r, test.output) {
+			t.Error("unexpected output: ", r, " for test case ", test)
+		}
+	}
+}
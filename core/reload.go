@@ -0,0 +1,235 @@
+package core
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/features"
+	"github.com/xtls/xray-core/features/inbound"
+	"github.com/xtls/xray-core/features/outbound"
+)
+
+// Reload applies config to the running instance without restarting it.
+//
+// Inbounds and outbounds are matched by tag, and only the handlers that were added, removed or
+// changed are replaced, so sessions on unchanged handlers keep running. Changed app settings are
+// applied to the features implementing features.Reloadable, such as the router and DNS. Changes
+// that can't be applied at runtime are logged and take effect after a restart.
+//
+// If reloading fails, the changes applied before the failure are kept, so that reloading again
+// only applies the rest.
+func (s *Instance) Reload(config *Config) error {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if !proto.Equal(s.config.Transport, config.Transport) {
+		newError("changes of global transport settings require a restart").AtWarning().WriteToLog()
+	}
+
+	// Each step records the changes it has applied in s.config.
+	s.config = proto.Clone(s.config).(*Config)
+	if err := s.reloadApps(config.App); err != nil {
+		return newError("failed to reload apps").Base(err)
+	}
+	if err := s.reloadOutbounds(config.Outbound); err != nil {
+		return newError("failed to reload outbounds").Base(err)
+	}
+	if err := s.reloadInbounds(config.Inbound); err != nil {
+		return newError("failed to reload inbounds").Base(err)
+	}
+
+	s.config = config
+	newError("config reloaded").AtWarning().WriteToLog()
+	return nil
+}
+
+func (s *Instance) reloadApps(apps []*serial.TypedMessage) error {
+	oldApps := make(map[string]int, len(s.config.App))
+	for i, app := range s.config.App {
+		oldApps[app.Type] = i
+	}
+
+	for _, app := range apps {
+		i, found := oldApps[app.Type]
+		delete(oldApps, app.Type)
+		if !found {
+			newError("adding ", app.Type, " requires a restart").AtWarning().WriteToLog()
+			continue
+		}
+		if proto.Equal(s.config.App[i], app) {
+			continue
+		}
+		feature, ok := s.appFeatures[app.Type].(features.Reloadable)
+		if !ok {
+			newError("changes of ", app.Type, " require a restart").AtWarning().WriteToLog()
+			continue
+		}
+		settings, err := app.GetInstance()
+		if err != nil {
+			return err
+		}
+		if err := feature.Reload(settings); err != nil {
+			return newError("failed to reload ", app.Type).Base(err)
+		}
+		s.config.App[i] = app
+		newError("reloaded ", app.Type).AtInfo().WriteToLog()
+	}
+
+	for appType := range oldApps {
+		newError("removing ", appType, " requires a restart").AtWarning().WriteToLog()
+	}
+	return nil
+}
+
+func (s *Instance) reloadOutbounds(configs []*OutboundHandlerConfig) error {
+	removed, added, untaggedChanged := diffHandlers(s.config.Outbound, configs)
+	if untaggedChanged {
+		newError("changes of outbounds without tag require a restart").AtWarning().WriteToLog()
+	}
+
+	// The first outbound is the default one. Outbound manager picks the first handler added
+	// after the default one is removed, so the new default must be re-added before others.
+	if len(s.config.Outbound) > 0 && len(configs) > 0 {
+		oldDefault, newDefault := s.config.Outbound[0], configs[0]
+		switch {
+		case proto.Equal(oldDefault, newDefault):
+		case len(oldDefault.Tag) == 0 || len(newDefault.Tag) == 0:
+			newError("changing the default outbound without tag requires a restart").AtWarning().WriteToLog()
+		default:
+			removed = appendTag(removed, oldDefault.Tag)
+			if findConfig(s.config.Outbound, newDefault.Tag) != nil {
+				removed = appendTag(removed, newDefault.Tag)
+			}
+			added = append([]*OutboundHandlerConfig{newDefault}, removeConfig(added, newDefault.Tag)...)
+			if c := findConfig(configs, oldDefault.Tag); c != nil && findConfig(added, oldDefault.Tag) == nil {
+				added = append(added, c)
+			}
+		}
+	}
+
+	manager := s.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	for _, tag := range removed {
+		if err := manager.RemoveHandler(s.ctx, tag); err != nil {
+			return newError("failed to remove outbound ", tag).Base(err)
+		}
+		s.config.Outbound = removeConfig(s.config.Outbound, tag)
+	}
+	for _, config := range added {
+		if err := AddOutboundHandler(s, config); err != nil {
+			return newError("failed to add outbound ", config.Tag).Base(err)
+		}
+		s.config.Outbound = addConfig(s.config.Outbound, config, config == configs[0])
+	}
+	return nil
+}
+
+func (s *Instance) reloadInbounds(configs []*InboundHandlerConfig) error {
+	removed, added, untaggedChanged := diffHandlers(s.config.Inbound, configs)
+	if untaggedChanged {
+		newError("changes of inbounds without tag require a restart").AtWarning().WriteToLog()
+	}
+
+	manager := s.GetFeature(inbound.ManagerType()).(inbound.Manager)
+	for _, tag := range removed {
+		// The handler may have been removed through API already.
+		if err := manager.RemoveHandler(s.ctx, tag); err != nil {
+			newError("failed to remove inbound ", tag).Base(err).AtWarning().WriteToLog()
+		}
+		s.config.Inbound = removeConfig(s.config.Inbound, tag)
+	}
+	for _, config := range added {
+		if err := AddInboundHandler(s, config); err != nil {
+			return newError("failed to add inbound ", config.Tag).Base(err)
+		}
+		s.config.Inbound = addConfig(s.config.Inbound, config, false)
+	}
+	return nil
+}
+
+type handlerConfig interface {
+	proto.Message
+	GetTag() string
+}
+
+// diffHandlers compares handler configs by tag. It returns the tags of the handlers to remove,
+// and the configs of the handlers to add afterwards in their original order. Handlers without tag
+// can't be replaced, so untaggedChanged reports whether any of them changed.
+func diffHandlers[T handlerConfig](oldConfigs, newConfigs []T) (removed []string, added []T, untaggedChanged bool) {
+	var oldUntagged, newUntagged []T
+	oldTagged := make(map[string]T, len(oldConfigs))
+	for _, config := range oldConfigs {
+		if len(config.GetTag()) == 0 {
+			oldUntagged = append(oldUntagged, config)
+			continue
+		}
+		oldTagged[config.GetTag()] = config
+	}
+
+	for _, config := range newConfigs {
+		tag := config.GetTag()
+		if len(tag) == 0 {
+			newUntagged = append(newUntagged, config)
+			continue
+		}
+		oldConfig, found := oldTagged[tag]
+		delete(oldTagged, tag)
+		if found && proto.Equal(oldConfig, config) {
+			continue
+		}
+		if found {
+			removed = append(removed, tag)
+		}
+		added = append(added, config)
+	}
+	for _, config := range oldConfigs {
+		if _, found := oldTagged[config.GetTag()]; found {
+			removed = append(removed, config.GetTag())
+		}
+	}
+
+	untaggedChanged = len(oldUntagged) != len(newUntagged)
+	for i := 0; !untaggedChanged && i < len(oldUntagged); i++ {
+		untaggedChanged = !proto.Equal(oldUntagged[i], newUntagged[i])
+	}
+	return
+}
+
+func appendTag(tags []string, tag string) []string {
+	for _, t := range tags {
+		if t == tag {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
+
+func findConfig(configs []*OutboundHandlerConfig, tag string) *OutboundHandlerConfig {
+	for _, config := range configs {
+		if config.Tag == tag {
+			return config
+		}
+	}
+	return nil
+}
+
+func removeConfig[T handlerConfig](configs []T, tag string) []T {
+	result := make([]T, 0, len(configs))
+	for _, config := range configs {
+		if config.GetTag() != tag {
+			result = append(result, config)
+		}
+	}
+	return result
+}
+
+// addConfig returns a copy of configs with config added to the front or the end.
+func addConfig[T handlerConfig](configs []T, config T, front bool) []T {
+	result := make([]T, 0, len(configs)+1)
+	if front {
+		result = append(result, config)
+	}
+	result = append(result, configs...)
+	if !front {
+		result = append(result, config)
+	}
+	return result
+}
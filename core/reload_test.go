@@ -0,0 +1,134 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xtls/xray-core/app/dispatcher"
+	"github.com/xtls/xray-core/app/proxyman"
+	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/common/session"
+	. "github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/outbound"
+	"github.com/xtls/xray-core/features/routing"
+	routing_session "github.com/xtls/xray-core/features/routing/session"
+	"github.com/xtls/xray-core/proxy/blackhole"
+	"github.com/xtls/xray-core/proxy/dokodemo"
+	"github.com/xtls/xray-core/proxy/freedom"
+	"github.com/xtls/xray-core/testing/servers/tcp"
+)
+
+func reloadTestConfig(blockTag string, outboundTag string) *Config {
+	return &Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&router.Config{
+				Rule: []*router.RoutingRule{
+					{
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: outboundTag,
+						},
+						Networks: []net.Network{net.Network_TCP},
+					},
+				},
+			}),
+		},
+		Outbound: []*OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+			{
+				Tag:           blockTag,
+				ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+			},
+		},
+	}
+}
+
+func TestReload(t *testing.T) {
+	server, err := New(reloadTestConfig("block", "direct"))
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	ohm := server.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	direct := ohm.GetHandler("direct")
+
+	common.Must(server.Reload(reloadTestConfig("reject", "reject")))
+
+	if ohm.GetHandler("direct") != direct {
+		t.Error("unchanged outbound is replaced")
+	}
+	if ohm.GetDefaultHandler() != direct {
+		t.Error("default outbound is changed")
+	}
+	if ohm.GetHandler("block") != nil {
+		t.Error("removed outbound still exists")
+	}
+	if ohm.GetHandler("reject") == nil {
+		t.Error("added outbound not found")
+	}
+
+	r := server.GetFeature(routing.RouterType()).(routing.Router)
+	ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{Target: net.TCPDestination(net.DomainAddress("example.com"), 80)})
+	route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+	common.Must(err)
+	if tag := route.GetOutboundTag(); tag != "reject" {
+		t.Error("expect tag 'reject' after reload, but actually ", tag)
+	}
+}
+
+func reloadTestInbound(tag string, port net.Port) *InboundHandlerConfig {
+	return &InboundHandlerConfig{
+		Tag: tag,
+		ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+			PortList: &net.PortList{
+				Range: []*net.PortRange{net.SinglePortRange(port)},
+			},
+			Listen: net.NewIPOrDomain(net.LocalHostIP),
+		}),
+		ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+			Address: net.NewIPOrDomain(net.LocalHostIP),
+			NetworkList: &net.NetworkList{
+				Network: []net.Network{net.Network_TCP},
+			},
+		}),
+	}
+}
+
+func TestReloadAfterFailure(t *testing.T) {
+	server, err := New(reloadTestConfig("block", "direct"))
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	// The outbound is added before adding the duplicated inbound fails.
+	config := reloadTestConfig("reject", "direct")
+	config.Inbound = []*InboundHandlerConfig{
+		reloadTestInbound("in", tcp.PickPort()),
+		reloadTestInbound("in", tcp.PickPort()),
+	}
+	if err := server.Reload(config); err == nil {
+		t.Fatal("expect error for duplicated inbound tag")
+	}
+
+	config = reloadTestConfig("reject", "direct")
+	config.Inbound = []*InboundHandlerConfig{
+		reloadTestInbound("in", tcp.PickPort()),
+	}
+	common.Must(server.Reload(config))
+
+	ohm := server.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	if ohm.GetHandler("reject") == nil {
+		t.Error("added outbound not found")
+	}
+	if ohm.GetHandler("block") != nil {
+		t.Error("removed outbound still exists")
+	}
+}
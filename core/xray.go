@@ -93,6 +93,10 @@ type Instance struct {
 	featureResolutions []resolution
 	running            bool
 
+	// config and appFeatures are kept for Reload.
+	config      *Config
+	appFeatures map[string]features.Feature
+
 	ctx context.Context
 }
 
@@ -190,6 +194,8 @@ func initInstanceWithConfig(config *Config, server *Instance) (bool, error) {
 		return true, err
 	}
 
+	server.config = config
+	server.appFeatures = make(map[string]features.Feature, len(config.App))
 	for _, appSettings := range config.App {
 		settings, err := appSettings.GetInstance()
 		if err != nil {
@@ -203,6 +209,7 @@ func initInstanceWithConfig(config *Config, server *Instance) (bool, error) {
 			if err := server.AddFeature(feature); err != nil {
 				return true, err
 			}
+			server.appFeatures[appSettings.Type] = feature
 		}
 	}
 
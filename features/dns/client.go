@@ -1,6 +1,8 @@
 package dns
 
 import (
+	"context"
+
 	"github.com/xtls/xray-core/common/errors"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/serial"
@@ -28,6 +30,21 @@ type HostsLookup interface {
 	LookupHosts(domain string) *net.Address
 }
 
+// ContextLookup is implemented by clients that choose name servers by the session of the query,
+// such as its inbound and user.
+type ContextLookup interface {
+	// LookupIPWithContext is LookupIP for the session in ctx.
+	LookupIPWithContext(ctx context.Context, domain string, option IPOption) ([]net.IP, error)
+}
+
+// LookupIPWithContext looks up IP address for the session in ctx, if the client supports it.
+func LookupIPWithContext(ctx context.Context, client Client, domain string, option IPOption) ([]net.IP, error) {
+	if c, ok := client.(ContextLookup); ok {
+		return c.LookupIPWithContext(ctx, domain, option)
+	}
+	return client.LookupIP(domain, option)
+}
+
 // ClientType returns the type of Client interface. Can be used for implementing common.HasType.
 //
 // xray:api:beta
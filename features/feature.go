@@ -15,3 +15,9 @@ type Feature interface {
 func PrintDeprecatedFeatureWarning(feature string) {
 	newError("You are using a deprecated feature: " + feature + ". Please update your config file with latest configuration format, or update your client software.").WriteToLog()
 }
+
+// Reloadable is implemented by features that can apply a new config while running.
+type Reloadable interface {
+	// Reload applies config, which is of the same type as the config the feature was created from.
+	Reload(config interface{}) error
+}
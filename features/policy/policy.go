@@ -5,8 +5,10 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/platform"
 	"github.com/xtls/xray-core/features"
+	"golang.org/x/time/rate"
 )
 
 // Timeout contains limits for connection timeout.
@@ -35,6 +37,24 @@ type Buffer struct {
 	PerConnection int32
 }
 
+// Bandwidth contains settings for bandwidth limit.
+type Bandwidth struct {
+	// Maximum uplink speed, in bytes per second. 0 for unlimited.
+	Uplink int64
+	// Maximum downlink speed, in bytes per second. 0 for unlimited.
+	Downlink int64
+}
+
+// Connection contains limits for concurrent connections of a user.
+type Connection struct {
+	// Maximum number of concurrent sessions. 0 for unlimited.
+	MaxSessions uint32
+	// Maximum number of distinct source IPs. 0 for unlimited.
+	MaxIPs uint32
+	// How long a source IP is still counted after its last session ends.
+	IPWindow time.Duration
+}
+
 // SystemStats contains stat policy settings on system level.
 type SystemStats struct {
 	// Whether or not to enable stat counter for uplink traffic in inbound handlers.
@@ -55,9 +75,11 @@ type System struct {
 
 // Session is session based settings for controlling Xray requests. It contains various settings (or limits) that may differ for different users in the context.
 type Session struct {
-	Timeouts Timeout // Timeout settings
-	Stats    Stats
-	Buffer   Buffer
+	Timeouts   Timeout // Timeout settings
+	Stats      Stats
+	Buffer     Buffer
+	Bandwidth  Bandwidth
+	Connection Connection
 }
 
 // Manager is a feature that provides Policy for the given user by its id or level.
@@ -73,6 +95,30 @@ type Manager interface {
 	ForSystem() System
 }
 
+// BandwidthManager is an optional interface of Manager for limiting bandwidth of users and inbounds.
+// Limiters are shared by all connections of the same user or inbound.
+type BandwidthManager interface {
+	// LimitersForUser returns the uplink and downlink limiters for the given user. Both are nil if the user is not limited.
+	// The returned function must be called when the session using the limiters ends.
+	LimitersForUser(level uint32, email string) (uplink *rate.Limiter, downlink *rate.Limiter, release func())
+
+	// LimitersForInbound returns the uplink and downlink limiters for the given inbound. Both are nil if the inbound is not limited.
+	LimitersForInbound(tag string) (uplink *rate.Limiter, downlink *rate.Limiter)
+
+	// SetLevelBandwidth changes the bandwidth limit for users of the given level.
+	SetLevelBandwidth(level uint32, bandwidth Bandwidth)
+
+	// SetInboundBandwidth changes the bandwidth limit for the given inbound.
+	SetInboundBandwidth(tag string, bandwidth Bandwidth)
+}
+
+// ConnectionLimiter is an optional interface of Manager for limiting concurrent connections of users.
+type ConnectionLimiter interface {
+	// AcquireSession registers a new session of the given user from the source address.
+	// It returns a function to be called when the session ends, or an error if the session exceeds any limit of the user.
+	AcquireSession(level uint32, email string, source net.Address) (release func(), err error)
+}
+
 // ManagerType returns the type of Manager interface. Can be used to implement common.HasType.
 //
 // xray:api:stable
@@ -2,6 +2,7 @@ package routing
 
 import (
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/process"
 )
 
 // Context is a feature to store connection information for routing.
@@ -40,4 +41,7 @@ type Context interface {
 
 	// GetSkipDNSResolve returns a flag switch for weather skip dns resolve during route pick.
 	GetSkipDNSResolve() bool
+
+	// GetSourceProcess returns the local process the connection is from, or nil if it can't be found.
+	GetSourceProcess() *process.Info
 }
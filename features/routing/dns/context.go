@@ -3,7 +3,11 @@ package dns
 //go:generate go run github.com/xtls/xray-core/common/errors/errorgen
 
 import (
+	"context"
+
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/protocol"
+	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/features/dns"
 	"github.com/xtls/xray-core/features/routing"
 )
@@ -22,7 +26,7 @@ func (ctx *ResolvableContext) GetTargetIPs() []net.IP {
 	}
 
 	if domain := ctx.GetTargetDomain(); len(domain) != 0 {
-		ips, err := ctx.dnsClient.LookupIP(domain, dns.IPOption{
+		ips, err := dns.LookupIPWithContext(ctx.sessionContext(), ctx.dnsClient, domain, dns.IPOption{
 			IPv4Enable: true,
 			IPv6Enable: true,
 			FakeEnable: false,
@@ -41,6 +45,16 @@ func (ctx *ResolvableContext) GetTargetIPs() []net.IP {
 	return nil
 }
 
+// sessionContext returns a session context with the inbound tag and user of the routing context,
+// so that name servers may be chosen by them.
+func (ctx *ResolvableContext) sessionContext() context.Context {
+	inbound := &session.Inbound{Tag: ctx.GetInboundTag()}
+	if user := ctx.GetUser(); len(user) > 0 {
+		inbound.User = &protocol.MemoryUser{Email: user}
+	}
+	return session.ContextWithInbound(context.Background(), inbound)
+}
+
 // ContextWithDNSClient creates a new routing context with domain resolving capability.
 // Resolved domain IPs can be retrieved by GetTargetIPs().
 func ContextWithDNSClient(ctx routing.Context, client dns.Client) routing.Context {
@@ -4,15 +4,21 @@ import (
 	"context"
 
 	"github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/process"
 	"github.com/xtls/xray-core/common/session"
 	"github.com/xtls/xray-core/features/routing"
 )
 
+//go:generate go run github.com/xtls/xray-core/common/errors/errorgen
+
 // Context is an implementation of routing.Context, which is a wrapper of context.context with session info.
 type Context struct {
 	Inbound  *session.Inbound
 	Outbound *session.Outbound
 	Content  *session.Content
+
+	process         *process.Info
+	processResolved bool
 }
 
 // GetInboundTag implements routing.Context.
@@ -122,6 +128,24 @@ func (ctx *Context) GetSkipDNSResolve() bool {
 	return ctx.Content.SkipDNSResolve
 }
 
+// GetSourceProcess implements routing.Context. The process is only looked up once, when it is first required.
+func (ctx *Context) GetSourceProcess() *process.Info {
+	if ctx.processResolved {
+		return ctx.process
+	}
+	ctx.processResolved = true
+	if ctx.Inbound == nil || !ctx.Inbound.Source.IsValid() {
+		return nil
+	}
+	info, err := process.FindProcess(ctx.Inbound.Source)
+	if err != nil {
+		newError("failed to find process of ", ctx.Inbound.Source).Base(err).AtDebug().WriteToLog()
+		return nil
+	}
+	ctx.process = info
+	return info
+}
+
 // AsRoutingContext creates a context from context.context with session info.
 func AsRoutingContext(ctx context.Context) routing.Context {
 	return &Context{
@@ -4,8 +4,10 @@ package stats
 
 import (
 	"context"
+	"time"
 
 	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/features"
 )
 
@@ -79,6 +81,33 @@ type Manager interface {
 	GetChannel(string) Channel
 }
 
+// Connection is an active connection tracked by ConnectionTracker.
+type Connection struct {
+	// ID is assigned by ConnectionTracker, and unique among all connections.
+	ID          uint64
+	SessionID   uint32
+	InboundTag  string
+	Email       string
+	Source      net.Destination
+	Target      net.Destination
+	Domain      string
+	OutboundTag string
+	Uplink      Counter
+	Downlink    Counter
+	StartTime   time.Time
+}
+
+// ConnectionTracker is an optional interface of Manager for tracking active connections.
+type ConnectionTracker interface {
+	// TrackConnection adds conn to active connections, and assigns its ID. The close function terminates conn.
+	// It returns a function to be called when the connection ends.
+	TrackConnection(conn *Connection, close func()) (untrack func())
+	// VisitConnections calls visitor function on all active connections.
+	VisitConnections(visitor func(*Connection) bool)
+	// CloseConnection terminates the active connection of the given ID.
+	CloseConnection(id uint64) error
+}
+
 // GetOrRegisterCounter tries to get the StatCounter first. If not exist, it then tries to create a new counter.
 func GetOrRegisterCounter(m Manager, name string) (Counter, error) {
 	counter := m.GetCounter(name)
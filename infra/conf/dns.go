@@ -15,8 +15,11 @@ type NameServerConfig struct {
 	ClientIP     *Address
 	Port         uint16
 	SkipFallback bool
+	TimeoutMs    uint32
 	Domains      []string
 	ExpectIPs    StringList
+	InboundTag   StringList
+	User         StringList
 }
 
 func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
@@ -31,16 +34,22 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 		ClientIP     *Address   `json:"clientIp"`
 		Port         uint16     `json:"port"`
 		SkipFallback bool       `json:"skipFallback"`
+		TimeoutMs    uint32     `json:"timeoutMs"`
 		Domains      []string   `json:"domains"`
 		ExpectIPs    StringList `json:"expectIps"`
+		InboundTag   StringList `json:"inboundTag"`
+		User         StringList `json:"user"`
 	}
 	if err := json.Unmarshal(data, &advanced); err == nil {
 		c.Address = advanced.Address
 		c.ClientIP = advanced.ClientIP
 		c.Port = advanced.Port
 		c.SkipFallback = advanced.SkipFallback
+		c.TimeoutMs = advanced.TimeoutMs
 		c.Domains = advanced.Domains
 		c.ExpectIPs = advanced.ExpectIPs
+		c.InboundTag = advanced.InboundTag
+		c.User = advanced.User
 		return nil
 	}
 
@@ -109,9 +118,12 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		},
 		ClientIp:          myClientIP,
 		SkipFallback:      c.SkipFallback,
+		TimeoutMs:         c.TimeoutMs,
 		PrioritizedDomain: domains,
 		Geoip:             geoipList,
 		OriginalRules:     originalRules,
+		InboundTag:        c.InboundTag,
+		UserEmail:         c.User,
 	}, nil
 }
 
@@ -124,14 +136,76 @@ var typeMap = map[router.Domain_Type]dns.DomainMatchingType{
 
 // DNSConfig is a JSON serializable object for dns.Config.
 type DNSConfig struct {
-	Servers                []*NameServerConfig `json:"servers"`
-	Hosts                  *HostsWrapper       `json:"hosts"`
-	ClientIP               *Address            `json:"clientIp"`
-	Tag                    string              `json:"tag"`
-	QueryStrategy          string              `json:"queryStrategy"`
-	DisableCache           bool                `json:"disableCache"`
-	DisableFallback        bool                `json:"disableFallback"`
-	DisableFallbackIfMatch bool                `json:"disableFallbackIfMatch"`
+	Servers                []*NameServerConfig   `json:"servers"`
+	Hosts                  *HostsWrapper         `json:"hosts"`
+	ClientIP               *Address              `json:"clientIp"`
+	Tag                    string                `json:"tag"`
+	QueryStrategy          string                `json:"queryStrategy"`
+	DisableCache           bool                  `json:"disableCache"`
+	DisableFallback        bool                  `json:"disableFallback"`
+	DisableFallbackIfMatch bool                  `json:"disableFallbackIfMatch"`
+	Cache                  *DNSCacheConfig       `json:"cache"`
+	ParallelQuery          bool                  `json:"parallelQuery"`
+	ParallelQueryCount     uint32                `json:"parallelQueryCount"`
+	HostsFiles             []*DNSHostsFileConfig `json:"hostsFiles"`
+	HostsFileCheckInterval uint32                `json:"hostsFileCheckInterval"`
+}
+
+// DNSHostsFileConfig is a file of static hosts, in the format of /etc/hosts or a domain list.
+type DNSHostsFileConfig struct {
+	Path   string `json:"path"`
+	Format string `json:"format"`
+	// Address is what domains in a domain list are mapped to.
+	Address *HostAddress `json:"address"`
+}
+
+// Build implements Buildable
+func (c *DNSHostsFileConfig) Build() (*dns.Config_HostsFile, error) {
+	if c.Path == "" {
+		return nil, newError("empty path of hosts file")
+	}
+	config := &dns.Config_HostsFile{
+		Path: c.Path,
+	}
+	switch strings.ToLower(c.Format) {
+	case "", "hosts":
+		config.Format = dns.Config_HostsFile_Hosts
+	case "domainlist", "domain_list", "domain-list":
+		if c.Address == nil {
+			return nil, newError("no address of domain list ", c.Path)
+		}
+		mapping := getHostMapping(c.Address)
+		config.Format = dns.Config_HostsFile_DomainList
+		config.Ip = mapping.Ip
+		config.ProxiedDomain = mapping.ProxiedDomain
+	default:
+		return nil, newError("unknown format of hosts file: ", c.Format)
+	}
+	return config, nil
+}
+
+type DNSCacheConfig struct {
+	MinTTL     uint32 `json:"minTTL"`
+	MaxTTL     uint32 `json:"maxTTL"`
+	ServeStale bool   `json:"serveStale"`
+	StaleTTL   uint32 `json:"staleTTL"`
+	Prefetch   bool   `json:"prefetch"`
+	MaxEntries uint32 `json:"maxEntries"`
+}
+
+// Build implements Buildable
+func (c *DNSCacheConfig) Build() (*dns.CacheConfig, error) {
+	if c.MaxTTL > 0 && c.MinTTL > c.MaxTTL {
+		return nil, newError("DNS cache minTTL ", c.MinTTL, " is larger than maxTTL ", c.MaxTTL)
+	}
+	return &dns.CacheConfig{
+		MinTtl:     c.MinTTL,
+		MaxTtl:     c.MaxTTL,
+		ServeStale: c.ServeStale,
+		StaleTtl:   c.StaleTTL,
+		Prefetch:   c.Prefetch,
+		MaxEntries: c.MaxEntries,
+	}, nil
 }
 
 type HostAddress struct {
@@ -311,6 +385,8 @@ func (c *DNSConfig) Build() (*dns.Config, error) {
 		DisableCache:           c.DisableCache,
 		DisableFallback:        c.DisableFallback,
 		DisableFallbackIfMatch: c.DisableFallbackIfMatch,
+		ParallelQuery:          c.ParallelQuery,
+		ParallelQueryCount:     c.ParallelQueryCount,
 	}
 
 	if c.ClientIP != nil {
@@ -346,5 +422,22 @@ func (c *DNSConfig) Build() (*dns.Config, error) {
 		config.StaticHosts = append(config.StaticHosts, staticHosts...)
 	}
 
+	for _, file := range c.HostsFiles {
+		hostsFile, err := file.Build()
+		if err != nil {
+			return nil, newError("failed to build hosts file").Base(err)
+		}
+		config.HostsFile = append(config.HostsFile, hostsFile)
+	}
+	config.HostsFileCheckInterval = c.HostsFileCheckInterval
+
+	if c.Cache != nil {
+		cache, err := c.Cache.Build()
+		if err != nil {
+			return nil, newError("failed to build DNS cache config").Base(err)
+		}
+		config.Cache = cache
+	}
+
 	return config, nil
 }
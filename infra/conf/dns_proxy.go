@@ -1,16 +1,45 @@
 package conf
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/proxy/dns"
 )
 
+var dnsQueryTypes = map[string]uint32{
+	"a":     1,
+	"ns":    2,
+	"cname": 5,
+	"soa":   6,
+	"ptr":   12,
+	"mx":    15,
+	"txt":   16,
+	"aaaa":  28,
+	"srv":   33,
+	"svcb":  64,
+	"https": 65,
+	"any":   255,
+}
+
+var dnsQueryActions = map[string]dns.QueryAction{
+	"default": dns.QueryAction_Default,
+	"forward": dns.QueryAction_Forward,
+	"empty":   dns.QueryAction_Empty,
+	"refuse":  dns.QueryAction_Refuse,
+	"drop":    dns.QueryAction_Drop,
+}
+
 type DNSOutboundConfig struct {
-	Network   Network  `json:"network"`
-	Address   *Address `json:"address"`
-	Port      uint16   `json:"port"`
-	UserLevel uint32   `json:"userLevel"`
+	Network      Network           `json:"network"`
+	Address      *Address          `json:"address"`
+	Port         uint16            `json:"port"`
+	UserLevel    uint32            `json:"userLevel"`
+	QueryTypes   map[string]string `json:"queryTypes"`
+	DisableCache bool              `json:"disableCache"`
 }
 
 func (c *DNSOutboundConfig) Build() (proto.Message, error) {
@@ -19,10 +48,41 @@ func (c *DNSOutboundConfig) Build() (proto.Message, error) {
 			Network: c.Network.Build(),
 			Port:    uint32(c.Port),
 		},
-		UserLevel: c.UserLevel,
+		UserLevel:    c.UserLevel,
+		DisableCache: c.DisableCache,
 	}
 	if c.Address != nil {
 		config.Server.Address = c.Address.Build()
 	}
+
+	types := make(map[dns.QueryAction][]uint32)
+	for name, actionName := range c.QueryTypes {
+		qType, found := dnsQueryTypes[strings.ToLower(name)]
+		if !found {
+			t, err := strconv.ParseUint(name, 10, 16)
+			if err != nil {
+				return nil, newError("unknown DNS query type: ", name)
+			}
+			qType = uint32(t)
+		}
+		action, found := dnsQueryActions[strings.ToLower(actionName)]
+		if !found {
+			return nil, newError("unknown action of DNS query type ", name, ": ", actionName)
+		}
+		types[action] = append(types[action], qType)
+	}
+	actions := make([]dns.QueryAction, 0, len(types))
+	for action := range types {
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	for _, action := range actions {
+		sort.Slice(types[action], func(i, j int) bool { return types[action][i] < types[action][j] })
+		config.QueryTypeRule = append(config.QueryTypeRule, &dns.QueryTypeRule{
+			Type:   types[action],
+			Action: action,
+		})
+	}
+
 	return config, nil
 }
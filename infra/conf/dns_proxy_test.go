@@ -29,5 +29,35 @@ func TestDnsProxyConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Input: `{
+				"queryTypes": {
+					"AAAA": "empty",
+					"HTTPS": "empty",
+					"TXT": "forward",
+					"99": "refuse"
+				},
+				"disableCache": true
+			}`,
+			Parser: loadJSON(creator),
+			Output: &dns.Config{
+				Server: &net.Endpoint{},
+				QueryTypeRule: []*dns.QueryTypeRule{
+					{
+						Type:   []uint32{16},
+						Action: dns.QueryAction_Forward,
+					},
+					{
+						Type:   []uint32{28, 65},
+						Action: dns.QueryAction_Empty,
+					},
+					{
+						Type:   []uint32{99},
+						Action: dns.QueryAction_Refuse,
+					},
+				},
+				DisableCache: true,
+			},
+		},
 	})
 }
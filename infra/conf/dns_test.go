@@ -145,5 +145,110 @@ func TestDNSConfigParsing(t *testing.T) {
 				DisableFallback: true,
 			},
 		},
+		{
+			Input: `{
+				"cache": {
+					"minTTL": 60,
+					"maxTTL": 3600,
+					"serveStale": true,
+					"prefetch": true,
+					"maxEntries": 1000
+				}
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				QueryStrategy: dns.QueryStrategy_USE_IP,
+				Cache: &dns.CacheConfig{
+					MinTtl:     60,
+					MaxTtl:     3600,
+					ServeStale: true,
+					Prefetch:   true,
+					MaxEntries: 1000,
+				},
+			},
+		},
+		{
+			Input: `{
+				"servers": [{
+					"address": "8.8.8.8",
+					"timeoutMs": 2000
+				}],
+				"parallelQuery": true,
+				"parallelQueryCount": 2
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				NameServer: []*dns.NameServer{
+					{
+						Address: &net.Endpoint{
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{8, 8, 8, 8},
+								},
+							},
+							Network: net.Network_UDP,
+						},
+						TimeoutMs: 2000,
+					},
+				},
+				QueryStrategy:      dns.QueryStrategy_USE_IP,
+				ParallelQuery:      true,
+				ParallelQueryCount: 2,
+			},
+		},
+		{
+			Input: `{
+				"servers": [{
+					"address": "8.8.8.8",
+					"inboundTag": ["corp-in"],
+					"user": "alice@example.com"
+				}]
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				NameServer: []*dns.NameServer{
+					{
+						Address: &net.Endpoint{
+							Address: &net.IPOrDomain{
+								Address: &net.IPOrDomain_Ip{
+									Ip: []byte{8, 8, 8, 8},
+								},
+							},
+							Network: net.Network_UDP,
+						},
+						InboundTag: []string{"corp-in"},
+						UserEmail:  []string{"alice@example.com"},
+					},
+				},
+				QueryStrategy: dns.QueryStrategy_USE_IP,
+			},
+		},
+		{
+			Input: `{
+				"hostsFiles": [{
+					"path": "/etc/hosts"
+				}, {
+					"path": "ads.txt",
+					"format": "domainList",
+					"address": ["0.0.0.0", "::"]
+				}],
+				"hostsFileCheckInterval": 30
+			}`,
+			Parser: parserCreator(),
+			Output: &dns.Config{
+				QueryStrategy: dns.QueryStrategy_USE_IP,
+				HostsFile: []*dns.Config_HostsFile{
+					{
+						Path: "/etc/hosts",
+					},
+					{
+						Path:   "ads.txt",
+						Format: dns.Config_HostsFile_DomainList,
+						Ip:     [][]byte{{0, 0, 0, 0}, make([]byte, 16)},
+					},
+				},
+				HostsFileCheckInterval: 30,
+			},
+		},
 	})
 }
@@ -2,9 +2,11 @@ package conf
 
 import (
 	"strings"
+	"time"
 
 	"github.com/xtls/xray-core/app/log"
 	clog "github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/infra/conf/cfgcommon/duration"
 )
 
 func DefaultLogConfig() *log.Config {
@@ -15,16 +17,43 @@ func DefaultLogConfig() *log.Config {
 	}
 }
 
+// LogRotationConfig is the rotation of log files. MaxSize is in MB.
+type LogRotationConfig struct {
+	MaxSize    uint32            `json:"maxSize"`
+	Interval   duration.Duration `json:"interval"`
+	MaxBackups uint32            `json:"maxBackups"`
+	Compress   bool              `json:"compress"`
+}
+
+func (c *LogRotationConfig) Build() (*log.LogRotation, error) {
+	interval := time.Duration(c.Interval)
+	if interval < 0 || interval%time.Second != 0 {
+		return nil, newError("invalid log rotation interval: ", interval)
+	}
+	if c.MaxSize == 0 && interval == 0 {
+		return nil, newError("either maxSize or interval of log rotation must be set")
+	}
+	return &log.LogRotation{
+		MaxSize:    int64(c.MaxSize) * 1024 * 1024,
+		Interval:   int64(interval / time.Second),
+		MaxBackups: c.MaxBackups,
+		Compress:   c.Compress,
+	}, nil
+}
+
 type LogConfig struct {
-	AccessLog string `json:"access"`
-	ErrorLog  string `json:"error"`
-	LogLevel  string `json:"loglevel"`
-	DNSLog    bool   `json:"dnsLog"`
+	AccessLog    string             `json:"access"`
+	ErrorLog     string             `json:"error"`
+	LogLevel     string             `json:"loglevel"`
+	DNSLog       bool               `json:"dnsLog"`
+	AccessFormat string             `json:"accessFormat"`
+	AccessFields []string           `json:"accessFields"`
+	Rotation     *LogRotationConfig `json:"rotation"`
 }
 
-func (v *LogConfig) Build() *log.Config {
+func (v *LogConfig) Build() (*log.Config, error) {
 	if v == nil {
-		return nil
+		return nil, nil
 	}
 	config := &log.Config{
 		ErrorLogType:  log.LogType_Console,
@@ -45,6 +74,31 @@ func (v *LogConfig) Build() *log.Config {
 		config.ErrorLogType = log.LogType_File
 	}
 
+	switch strings.ToLower(v.AccessFormat) {
+	case "", "text":
+		if len(v.AccessFields) > 0 {
+			return nil, newError("accessFields is only supported by json access log")
+		}
+	case "json":
+		config.AccessLogFormat = log.AccessLogFormat_JSON
+		config.AccessLogFields = v.AccessFields
+	default:
+		return nil, newError("unknown access log format: ", v.AccessFormat)
+	}
+
+	if v.Rotation != nil {
+		rotation, err := v.Rotation.Build()
+		if err != nil {
+			return nil, err
+		}
+		if config.AccessLogType == log.LogType_File {
+			config.AccessLogRotation = rotation
+		}
+		if config.ErrorLogType == log.LogType_File {
+			config.ErrorLogRotation = rotation
+		}
+	}
+
 	level := strings.ToLower(v.LogLevel)
 	switch level {
 	case "debug":
@@ -59,5 +113,5 @@ func (v *LogConfig) Build() *log.Config {
 	default:
 		config.ErrorLogLevel = clog.Severity_Warning
 	}
-	return config
+	return config, nil
 }
@@ -1,11 +1,15 @@
 package conf
 
 import (
+	"strings"
+
 	"github.com/xtls/xray-core/app/metrics"
 )
 
 type MetricsConfig struct {
-	Tag string `json:"tag"`
+	Tag             string `json:"tag"`
+	OpenMetrics     bool   `json:"openMetrics"`
+	OpenMetricsPath string `json:"openMetricsPath"`
 }
 
 func (c *MetricsConfig) Build() (*metrics.Config, error) {
@@ -13,7 +17,13 @@ func (c *MetricsConfig) Build() (*metrics.Config, error) {
 		return nil, newError("metrics tag can't be empty.")
 	}
 
+	if c.OpenMetricsPath != "" && !strings.HasPrefix(c.OpenMetricsPath, "/") {
+		return nil, newError("metrics openMetricsPath must start with '/'.")
+	}
+
 	return &metrics.Config{
-		Tag: c.Tag,
+		Tag:             c.Tag,
+		OpenMetrics:     c.OpenMetrics,
+		OpenMetricsPath: c.OpenMetricsPath,
 	}, nil
 }
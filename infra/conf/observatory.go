@@ -1,18 +1,51 @@
 package conf
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/app/observatory"
 	"github.com/xtls/xray-core/infra/conf/cfgcommon/duration"
 )
 
 type ObservatoryConfig struct {
-	SubjectSelector   []string          `json:"subjectSelector"`
-	ProbeURL          string            `json:"probeURL"`
-	ProbeInterval     duration.Duration `json:"probeInterval"`
-	EnableConcurrency bool              `json:"enableConcurrency"`
+	SubjectSelector   []string                `json:"subjectSelector"`
+	ProbeURL          string                  `json:"probeURL"`
+	ProbeInterval     duration.Duration       `json:"probeInterval"`
+	EnableConcurrency bool                    `json:"enableConcurrency"`
+	ProbeMethod       string                  `json:"probeMethod"`
+	ExpectedStatus    []uint32                `json:"expectedStatus"`
+	Burst             *BurstObservatoryConfig `json:"burst"`
+}
+
+type BurstObservatoryConfig struct {
+	SamplingCount uint32 `json:"samplingCount"`
+	WindowSize    uint32 `json:"windowSize"`
 }
 
 func (o *ObservatoryConfig) Build() (proto.Message, error) {
-	return &observatory.Config{SubjectSelector: o.SubjectSelector, ProbeUrl: o.ProbeURL, ProbeInterval: int64(o.ProbeInterval), EnableConcurrency: o.EnableConcurrency}, nil
+	config := &observatory.Config{SubjectSelector: o.SubjectSelector, ProbeUrl: o.ProbeURL, ProbeInterval: int64(o.ProbeInterval), EnableConcurrency: o.EnableConcurrency}
+
+	switch strings.ToUpper(o.ProbeMethod) {
+	case "", http.MethodGet:
+	case http.MethodHead:
+		config.ProbeMethod = http.MethodHead
+	default:
+		return nil, newError("unsupported probe method: ", o.ProbeMethod)
+	}
+	for _, code := range o.ExpectedStatus {
+		if code < 100 || code > 599 {
+			return nil, newError("invalid expected status: ", code)
+		}
+	}
+	config.ExpectedStatus = o.ExpectedStatus
+
+	if o.Burst != nil {
+		config.Burst = &observatory.BurstConfig{
+			SamplingCount: o.Burst.SamplingCount,
+			WindowSize:    o.Burst.WindowSize,
+		}
+	}
+	return config, nil
 }
@@ -12,6 +12,11 @@ type Policy struct {
 	StatsUserUplink   bool    `json:"statsUserUplink"`
 	StatsUserDownlink bool    `json:"statsUserDownlink"`
 	BufferSize        *int32  `json:"bufferSize"`
+	UplinkSpeed       uint64  `json:"uplinkSpeed"`
+	DownlinkSpeed     uint64  `json:"downlinkSpeed"`
+	MaxSessions       uint32  `json:"maxSessions"`
+	MaxIPs            uint32  `json:"maxIPs"`
+	IPWindow          uint32  `json:"ipWindow"`
 }
 
 func (t *Policy) Build() (*policy.Policy, error) {
@@ -47,9 +52,37 @@ func (t *Policy) Build() (*policy.Policy, error) {
 		}
 	}
 
+	if t.UplinkSpeed > 0 || t.DownlinkSpeed > 0 {
+		p.Bandwidth = &policy.Policy_Bandwidth{
+			Uplink:   t.UplinkSpeed * 1024,
+			Downlink: t.DownlinkSpeed * 1024,
+		}
+	}
+
+	if t.MaxSessions > 0 || t.MaxIPs > 0 {
+		p.Connection = &policy.Policy_Connection{
+			MaxSessions: t.MaxSessions,
+			MaxIps:      t.MaxIPs,
+			IpWindow:    &policy.Second{Value: t.IPWindow},
+		}
+	}
+
 	return p, nil
 }
 
+// BandwidthPolicy is the bandwidth limit of an inbound, in KB/s.
+type BandwidthPolicy struct {
+	UplinkSpeed   uint64 `json:"uplinkSpeed"`
+	DownlinkSpeed uint64 `json:"downlinkSpeed"`
+}
+
+func (b *BandwidthPolicy) Build() (*policy.Policy_Bandwidth, error) {
+	return &policy.Policy_Bandwidth{
+		Uplink:   b.UplinkSpeed * 1024,
+		Downlink: b.DownlinkSpeed * 1024,
+	}, nil
+}
+
 type SystemPolicy struct {
 	StatsInboundUplink    bool `json:"statsInboundUplink"`
 	StatsInboundDownlink  bool `json:"statsInboundDownlink"`
@@ -69,8 +102,9 @@ func (p *SystemPolicy) Build() (*policy.SystemPolicy, error) {
 }
 
 type PolicyConfig struct {
-	Levels map[uint32]*Policy `json:"levels"`
-	System *SystemPolicy      `json:"system"`
+	Levels   map[uint32]*Policy          `json:"levels"`
+	System   *SystemPolicy               `json:"system"`
+	Inbounds map[string]*BandwidthPolicy `json:"inbounds"`
 }
 
 func (c *PolicyConfig) Build() (*policy.Config, error) {
@@ -96,5 +130,19 @@ func (c *PolicyConfig) Build() (*policy.Config, error) {
 		config.System = sc
 	}
 
+	if len(c.Inbounds) > 0 {
+		config.InboundBandwidth = make(map[string]*policy.Policy_Bandwidth)
+		for tag, b := range c.Inbounds {
+			if b == nil {
+				continue
+			}
+			bc, err := b.Build()
+			if err != nil {
+				return nil, err
+			}
+			config.InboundBandwidth[tag] = bc
+		}
+	}
+
 	return config, nil
 }
@@ -38,3 +38,26 @@ func TestBufferSize(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicyBandwidth(t *testing.T) {
+	pConf := Policy{
+		UplinkSpeed:   1,
+		DownlinkSpeed: 2,
+	}
+	p, err := pConf.Build()
+	common.Must(err)
+	if p.Bandwidth.Uplink != 1024 || p.Bandwidth.Downlink != 2048 {
+		t.Error("unexpected bandwidth ", p.Bandwidth)
+	}
+
+	cConf := PolicyConfig{
+		Inbounds: map[string]*BandwidthPolicy{
+			"in": {DownlinkSpeed: 4},
+		},
+	}
+	c, err := cConf.Build()
+	common.Must(err)
+	if b := c.InboundBandwidth["in"]; b.Uplink != 0 || b.Downlink != 4096 {
+		t.Error("unexpected inbound bandwidth ", b)
+	}
+}
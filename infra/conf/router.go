@@ -5,11 +5,13 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/app/router"
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/platform/filesystem"
+	"github.com/xtls/xray-core/common/serial"
 )
 
 type RouterRulesConfig struct {
@@ -24,9 +26,10 @@ type StrategyConfig struct {
 }
 
 type BalancingRule struct {
-	Tag       string         `json:"tag"`
-	Selectors StringList     `json:"selector"`
-	Strategy  StrategyConfig `json:"strategy"`
+	Tag         string         `json:"tag"`
+	Selectors   StringList     `json:"selector"`
+	Strategy    StrategyConfig `json:"strategy"`
+	FallbackTag string         `json:"fallbackTag"`
 }
 
 func (r *BalancingRule) Build() (*router.BalancingRule, error) {
@@ -38,20 +41,41 @@ func (r *BalancingRule) Build() (*router.BalancingRule, error) {
 	}
 
 	var strategy string
+	var settings Buildable
 	switch strings.ToLower(r.Strategy.Type) {
 	case strategyRandom, "":
 		strategy = strategyRandom
 	case strategyLeastPing:
 		strategy = "leastPing"
+	case strategyLeastLoad:
+		strategy = "leastLoad"
+		settings = new(strategyLeastLoadConfig)
+	case strategyRoundRobin:
+		strategy = "roundRobin"
+	case strategyWeighted:
+		strategy = "weighted"
+		settings = new(strategyWeightedConfig)
 	default:
 		return nil, newError("unknown balancing strategy: " + r.Strategy.Type)
 	}
 
-	return &router.BalancingRule{
+	rule := &router.BalancingRule{
 		Tag:              r.Tag,
 		OutboundSelector: []string(r.Selectors),
 		Strategy:         strategy,
-	}, nil
+		FallbackTag:      r.FallbackTag,
+	}
+	if settings != nil && r.Strategy.Settings != nil {
+		if err := json.Unmarshal(*r.Strategy.Settings, settings); err != nil {
+			return nil, newError("invalid settings of balancing strategy ", r.Strategy.Type).Base(err)
+		}
+		s, err := settings.Build()
+		if err != nil {
+			return nil, err
+		}
+		rule.StrategySettings = serial.ToTypedMessage(s)
+	}
+	return rule, nil
 }
 
 type RouterConfig struct {
@@ -61,6 +85,7 @@ type RouterConfig struct {
 	Balancers      []*BalancingRule   `json:"balancers"`
 
 	DomainMatcher string `json:"domainMatcher"`
+	RuleStats     bool   `json:"ruleStats"`
 }
 
 func (c *RouterConfig) getDomainStrategy() router.Config_DomainStrategy {
@@ -86,6 +111,7 @@ func (c *RouterConfig) getDomainStrategy() router.Config_DomainStrategy {
 func (c *RouterConfig) Build() (*router.Config, error) {
 	config := new(router.Config)
 	config.DomainStrategy = c.getDomainStrategy()
+	config.RuleStats = c.RuleStats
 
 	var rawRuleList []json.RawMessage
 	if c != nil {
@@ -122,10 +148,129 @@ type RouterRule struct {
 	Type        string `json:"type"`
 	OutboundTag string `json:"outboundTag"`
 	BalancerTag string `json:"balancerTag"`
+	RuleTag     string `json:"ruleTag"`
 
 	DomainMatcher string `json:"domainMatcher"`
 }
 
+// RuleSetConfig is a rule-set file referenced by routing rules.
+type RuleSetConfig struct {
+	Path   string `json:"path"`
+	Format string `json:"format"`
+}
+
+// Build implements Buildable.
+func (c *RuleSetConfig) Build() (*router.RuleSet, error) {
+	if len(c.Path) == 0 {
+		return nil, newError("empty path of rule set")
+	}
+	ruleSet := &router.RuleSet{
+		Path: c.Path,
+	}
+	switch strings.ToLower(c.Format) {
+	case "", "domain":
+		ruleSet.Format = router.RuleSet_DomainList
+	case "ip", "cidr":
+		ruleSet.Format = router.RuleSet_CIDRList
+	case "binary":
+		ruleSet.Format = router.RuleSet_Binary
+	default:
+		return nil, newError("unknown format of rule set: ", c.Format)
+	}
+	return ruleSet, nil
+}
+
+// ScheduleConfig is a time window in which a routing rule is active.
+type ScheduleConfig struct {
+	// Weekday is a list of days or ranges of days, such as "sat" or "mon-fri".
+	Weekday *StringList `json:"weekday"`
+	// Time is a list of ranges of time of day, such as "22:00-06:00".
+	Time     *StringList `json:"time"`
+	TimeZone string      `json:"timeZone"`
+}
+
+// parseWeekday parses the name of a day, such as "mon" or "monday".
+func parseWeekday(s string) (uint32, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if s == name || s == name[:3] {
+			return uint32(day), nil
+		}
+	}
+	return 0, newError("invalid weekday: ", s)
+}
+
+// parseTimeOfDay parses a time of day such as "06:00" into minutes since midnight.
+func parseTimeOfDay(s string) (uint32, error) {
+	t := strings.Split(strings.TrimSpace(s), ":")
+	if len(t) != 2 {
+		return 0, newError("invalid time: ", s)
+	}
+	hour, err := strconv.ParseUint(t[0], 10, 32)
+	if err != nil {
+		return 0, newError("invalid time: ", s).Base(err)
+	}
+	minute, err := strconv.ParseUint(t[1], 10, 32)
+	if err != nil {
+		return 0, newError("invalid time: ", s).Base(err)
+	}
+	if minute >= 60 || hour > 24 || (hour == 24 && minute > 0) {
+		return 0, newError("invalid time: ", s)
+	}
+	return uint32(hour*60 + minute), nil
+}
+
+// Build implements Buildable.
+func (c *ScheduleConfig) Build() (*router.Schedule, error) {
+	schedule := &router.Schedule{
+		TimeZone: c.TimeZone,
+	}
+	if c.Weekday != nil {
+		for _, s := range *c.Weekday {
+			days := strings.SplitN(s, "-", 2)
+			from, err := parseWeekday(days[0])
+			if err != nil {
+				return nil, err
+			}
+			to := from
+			if len(days) == 2 {
+				if to, err = parseWeekday(days[1]); err != nil {
+					return nil, err
+				}
+			}
+			// Ranges such as "fri-mon" span the weekend.
+			for day := from; ; day = (day + 1) % 7 {
+				schedule.Weekday = append(schedule.Weekday, day)
+				if day == to {
+					break
+				}
+			}
+		}
+	}
+	if c.Time != nil {
+		for _, s := range *c.Time {
+			times := strings.SplitN(s, "-", 2)
+			if len(times) != 2 {
+				return nil, newError("invalid time range: ", s)
+			}
+			from, err := parseTimeOfDay(times[0])
+			if err != nil {
+				return nil, err
+			}
+			to, err := parseTimeOfDay(times[1])
+			if err != nil {
+				return nil, err
+			}
+			schedule.TimeRange = append(schedule.TimeRange, &router.Schedule_TimeRange{
+				From: from,
+				To:   to,
+			})
+		}
+	}
+	return schedule, nil
+}
+
 func ParseIP(s string) (*router.CIDR, error) {
 	var addr, mask string
 	i := strings.Index(s, "/")
@@ -504,17 +649,22 @@ func ToCidrList(ips StringList) ([]*router.GeoIP, error) {
 func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 	type RawFieldRule struct {
 		RouterRule
-		Domain     *StringList  `json:"domain"`
-		Domains    *StringList  `json:"domains"`
-		IP         *StringList  `json:"ip"`
-		Port       *PortList    `json:"port"`
-		Network    *NetworkList `json:"network"`
-		SourceIP   *StringList  `json:"source"`
-		SourcePort *PortList    `json:"sourcePort"`
-		User       *StringList  `json:"user"`
-		InboundTag *StringList  `json:"inboundTag"`
-		Protocols  *StringList  `json:"protocol"`
-		Attributes string       `json:"attrs"`
+		Domain      *StringList      `json:"domain"`
+		Domains     *StringList      `json:"domains"`
+		IP          *StringList      `json:"ip"`
+		Port        *PortList        `json:"port"`
+		Network     *NetworkList     `json:"network"`
+		SourceIP    *StringList      `json:"source"`
+		SourcePort  *PortList        `json:"sourcePort"`
+		User        *StringList      `json:"user"`
+		InboundTag  *StringList      `json:"inboundTag"`
+		Protocols   *StringList      `json:"protocol"`
+		Attributes  string           `json:"attrs"`
+		RuleSet     []*RuleSetConfig `json:"ruleSet"`
+		ProcessName *StringList      `json:"processName"`
+		ProcessPath *StringList      `json:"processPath"`
+		UID         []uint32         `json:"uid"`
+		Schedule    *ScheduleConfig  `json:"schedule"`
 	}
 	rawFieldRule := new(RawFieldRule)
 	err := json.Unmarshal(msg, rawFieldRule)
@@ -536,6 +686,8 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 		return nil, newError("neither outboundTag nor balancerTag is specified in routing rule")
 	}
 
+	rule.RuleTag = rawFieldRule.RuleTag
+
 	if rawFieldRule.DomainMatcher != "" {
 		rule.DomainMatcher = rawFieldRule.DomainMatcher
 	}
@@ -568,6 +720,14 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 		rule.Geoip = geoipList
 	}
 
+	for _, ruleSet := range rawFieldRule.RuleSet {
+		rs, err := ruleSet.Build()
+		if err != nil {
+			return nil, err
+		}
+		rule.RuleSet = append(rule.RuleSet, rs)
+	}
+
 	if rawFieldRule.Port != nil {
 		rule.PortList = rawFieldRule.Port.Build()
 	}
@@ -610,6 +770,28 @@ func parseFieldRule(msg json.RawMessage) (*router.RoutingRule, error) {
 		rule.Attributes = rawFieldRule.Attributes
 	}
 
+	if rawFieldRule.ProcessName != nil {
+		for _, s := range *rawFieldRule.ProcessName {
+			rule.ProcessName = append(rule.ProcessName, s)
+		}
+	}
+
+	if rawFieldRule.ProcessPath != nil {
+		for _, s := range *rawFieldRule.ProcessPath {
+			rule.ProcessPath = append(rule.ProcessPath, s)
+		}
+	}
+
+	rule.Uid = rawFieldRule.UID
+
+	if rawFieldRule.Schedule != nil {
+		schedule, err := rawFieldRule.Schedule.Build()
+		if err != nil {
+			return nil, err
+		}
+		rule.Schedule = schedule
+	}
+
 	return rule, nil
 }
 
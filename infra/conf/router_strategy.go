@@ -1,6 +1,61 @@
 package conf
 
+import (
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xtls/xray-core/app/router"
+	"github.com/xtls/xray-core/infra/conf/cfgcommon/duration"
+)
+
 const (
-	strategyRandom    string = "random"
-	strategyLeastPing string = "leastping"
+	strategyRandom     string = "random"
+	strategyLeastPing  string = "leastping"
+	strategyLeastLoad  string = "leastload"
+	strategyRoundRobin string = "roundrobin"
+	strategyWeighted   string = "weighted"
 )
+
+type strategyWeightedConfig struct {
+	// Weights maps prefixes of outbound tags to weights.
+	Weights map[string]uint32 `json:"weights"`
+}
+
+// Build implements Buildable.
+func (c *strategyWeightedConfig) Build() (proto.Message, error) {
+	config := new(router.StrategyWeightedConfig)
+	for selector, weight := range c.Weights {
+		config.Weights = append(config.Weights, &router.StrategyWeight{
+			Selector: selector,
+			Weight:   weight,
+		})
+	}
+	// Longer selectors are more specific, so check them first.
+	sort.Slice(config.Weights, func(i, j int) bool {
+		a, b := config.Weights[i].Selector, config.Weights[j].Selector
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a < b
+	})
+	return config, nil
+}
+
+type strategyLeastLoadConfig struct {
+	MaxRTT    duration.Duration `json:"maxRTT"`
+	Tolerance float32           `json:"tolerance"`
+	Expected  uint32            `json:"expected"`
+}
+
+// Build implements Buildable.
+func (c *strategyLeastLoadConfig) Build() (proto.Message, error) {
+	if c.Tolerance < 0 || c.Tolerance > 1 {
+		return nil, newError("tolerance must be between 0 and 1")
+	}
+	return &router.StrategyLeastLoadConfig{
+		MaxRtt:    time.Duration(c.MaxRTT).Milliseconds(),
+		Tolerance: c.Tolerance,
+		Expected:  c.Expected,
+	}, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/xtls/xray-core/common/net"
 	"github.com/xtls/xray-core/common/platform"
 	"github.com/xtls/xray-core/common/platform/filesystem"
+	"github.com/xtls/xray-core/common/serial"
 	. "github.com/xtls/xray-core/infra/conf"
 )
 
@@ -298,5 +299,195 @@ func TestRouterConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Input: `{
+				"rules": [
+					{
+						"type": "field",
+						"ruleSet": [
+							{"path": "abuse.txt"},
+							{"path": "abuse-ip.txt", "format": "ip"},
+							{"path": "abuse.dat", "format": "binary"}
+						],
+						"outboundTag": "block"
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.Config_AsIs,
+				Rule: []*router.RoutingRule{
+					{
+						RuleSet: []*router.RuleSet{
+							{
+								Path: "abuse.txt",
+							},
+							{
+								Path:   "abuse-ip.txt",
+								Format: router.RuleSet_CIDRList,
+							},
+							{
+								Path:   "abuse.dat",
+								Format: router.RuleSet_Binary,
+							},
+						},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "block",
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: `{
+				"rules": [
+					{
+						"type": "field",
+						"processName": ["firefox", "steam"],
+						"processPath": "/usr/bin/telegram-desktop",
+						"uid": [1000],
+						"outboundTag": "direct"
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.Config_AsIs,
+				Rule: []*router.RoutingRule{
+					{
+						ProcessName: []string{"firefox", "steam"},
+						ProcessPath: []string{"/usr/bin/telegram-desktop"},
+						Uid:         []uint32{1000},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "direct",
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: `{
+				"ruleStats": true,
+				"rules": [
+					{
+						"type": "field",
+						"network": "tcp",
+						"ruleTag": "night",
+						"schedule": {
+							"weekday": ["fri-mon", "Wednesday"],
+							"time": "22:30-06:00,12:00-24:00",
+							"timeZone": "Asia/Shanghai"
+						},
+						"outboundTag": "cheap"
+					}
+				]
+			}`,
+			Parser: createParser(),
+			Output: &router.Config{
+				DomainStrategy: router.Config_AsIs,
+				RuleStats:      true,
+				Rule: []*router.RoutingRule{
+					{
+						Networks: []net.Network{net.Network_TCP},
+						RuleTag:  "night",
+						Schedule: &router.Schedule{
+							Weekday: []uint32{5, 6, 0, 1, 3},
+							TimeRange: []*router.Schedule_TimeRange{
+								{From: 22*60 + 30, To: 6 * 60},
+								{From: 12 * 60, To: 24 * 60},
+							},
+							TimeZone: "Asia/Shanghai",
+						},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "cheap",
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestBalancingRuleStrategy(t *testing.T) {
+	createParser := func() func(string) (proto.Message, error) {
+		return func(s string) (proto.Message, error) {
+			config := new(BalancingRule)
+			if err := json.Unmarshal([]byte(s), config); err != nil {
+				return nil, err
+			}
+			return config.Build()
+		}
+	}
+
+	runMultiTestCase(t, []TestCase{
+		{
+			Input: `{
+				"tag": "b1",
+				"selector": ["proxy"],
+				"fallbackTag": "direct",
+				"strategy": {
+					"type": "leastLoad",
+					"settings": {
+						"maxRTT": "1s",
+						"tolerance": 0.1,
+						"expected": 2
+					}
+				}
+			}`,
+			Parser: createParser(),
+			Output: &router.BalancingRule{
+				Tag:              "b1",
+				OutboundSelector: []string{"proxy"},
+				Strategy:         "leastLoad",
+				FallbackTag:      "direct",
+				StrategySettings: serial.ToTypedMessage(&router.StrategyLeastLoadConfig{
+					MaxRtt:    1000,
+					Tolerance: 0.1,
+					Expected:  2,
+				}),
+			},
+		},
+		{
+			Input: `{
+				"tag": "b2",
+				"selector": ["proxy"],
+				"strategy": {
+					"type": "weighted",
+					"settings": {
+						"weights": {
+							"proxy": 1,
+							"proxy-hk": 3
+						}
+					}
+				}
+			}`,
+			Parser: createParser(),
+			Output: &router.BalancingRule{
+				Tag:              "b2",
+				OutboundSelector: []string{"proxy"},
+				Strategy:         "weighted",
+				StrategySettings: serial.ToTypedMessage(&router.StrategyWeightedConfig{
+					Weights: []*router.StrategyWeight{
+						{Selector: "proxy-hk", Weight: 3},
+						{Selector: "proxy", Weight: 1},
+					},
+				}),
+			},
+		},
+		{
+			Input: `{
+				"tag": "b3",
+				"selector": ["proxy"],
+				"strategy": {
+					"type": "roundRobin"
+				}
+			}`,
+			Parser: createParser(),
+			Output: &router.BalancingRule{
+				Tag:              "b3",
+				OutboundSelector: []string{"proxy"},
+				Strategy:         "roundRobin",
+			},
+		},
 	})
 }
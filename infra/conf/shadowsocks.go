@@ -36,6 +36,8 @@ type ShadowsocksUserConfig struct {
 	Email    string   `json:"email"`
 	Address  *Address `json:"address"`
 	Port     uint16   `json:"port"`
+	Quota    uint64   `json:"quota"`
+	Expiry   int64    `json:"expiry"`
 }
 
 type ShadowsocksServerConfig struct {
@@ -73,6 +75,8 @@ func (v *ShadowsocksServerConfig) Build() (proto.Message, error) {
 			config.Users = append(config.Users, &protocol.User{
 				Email:   user.Email,
 				Level:   uint32(user.Level),
+				Quota:   user.Quota,
+				Expiry:  user.Expiry,
 				Account: serial.ToTypedMessage(account),
 			})
 		}
@@ -94,6 +94,8 @@ type TrojanUserConfig struct {
 	Level    byte   `json:"level"`
 	Email    string `json:"email"`
 	Flow     string `json:"flow"`
+	Quota    uint64 `json:"quota"`
+	Expiry   int64  `json:"expiry"`
 }
 
 // TrojanServerConfig is Inbound configuration
@@ -122,6 +124,8 @@ func (c *TrojanServerConfig) Build() (proto.Message, error) {
 
 		user.Email = rawUser.Email
 		user.Level = uint32(rawUser.Level)
+		user.Quota = rawUser.Quota
+		user.Expiry = rawUser.Expiry
 		user.Account = serial.ToTypedMessage(account)
 		config.Users[idx] = user
 	}
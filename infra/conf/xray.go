@@ -7,12 +7,15 @@ import (
 	"os"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/app/dispatcher"
 	"github.com/xtls/xray-core/app/proxyman"
 	"github.com/xtls/xray-core/app/stats"
+	"github.com/xtls/xray-core/common/protocol"
 	"github.com/xtls/xray-core/common/serial"
 	core "github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/transport/internet"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 var (
@@ -586,7 +589,11 @@ func (c *Config) Build() (*core.Config, error) {
 
 	var logConfMsg *serial.TypedMessage
 	if c.LogConfig != nil {
-		logConfMsg = serial.ToTypedMessage(c.LogConfig.Build())
+		logConfig, err := c.LogConfig.Build()
+		if err != nil {
+			return nil, newError("failed to build log configuration").Base(err)
+		}
+		logConfMsg = serial.ToTypedMessage(logConfig)
 	} else {
 		logConfMsg = serial.ToTypedMessage(DefaultLogConfig())
 	}
@@ -683,6 +690,12 @@ func (c *Config) Build() (*core.Config, error) {
 		if err != nil {
 			return nil, err
 		}
+		if c.Stats == nil {
+			// Traffic quota of users is counted by the stats manager.
+			if settings, err := ic.ProxySettings.GetInstance(); err == nil && hasUserQuota(proto.MessageReflect(settings)) {
+				return nil, newError("traffic quota of users in inbound ", ic.Tag, " requires stats")
+			}
+		}
 		config.Inbound = append(config.Inbound, ic)
 	}
 
@@ -716,3 +729,26 @@ func (c *Config) Build() (*core.Config, error) {
 
 	return config, nil
 }
+
+// hasUserQuota returns whether there is any user with traffic quota in message.
+func hasUserQuota(message protoreflect.Message) bool {
+	if user, ok := message.Interface().(*protocol.User); ok {
+		return user.Quota > 0
+	}
+	found := false
+	message.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsMap() {
+			return true
+		}
+		if fd.IsList() {
+			list := v.List()
+			for i := 0; i < list.Len() && !found; i++ {
+				found = hasUserQuota(list.Get(i).Message())
+			}
+		} else {
+			found = hasUserQuota(v.Message())
+		}
+		return !found
+	})
+	return found
+}
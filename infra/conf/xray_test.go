@@ -466,3 +466,25 @@ func TestConfig_Override(t *testing.T) {
 		})
 	}
 }
+
+func TestUserQuotaRequiresStats(t *testing.T) {
+	inbound := `"inbounds": [{
+		"protocol": "trojan",
+		"port": 443,
+		"settings": {
+			"clients": [{"password": "password", "email": "test", "quota": 1000}]
+		}
+	}]`
+
+	config := new(Config)
+	common.Must(json.Unmarshal([]byte(`{`+inbound+`}`), config))
+	if _, err := config.Build(); err == nil {
+		t.Error("expect error for quota without stats")
+	}
+
+	config = new(Config)
+	common.Must(json.Unmarshal([]byte(`{"stats": {}, `+inbound+`}`), config))
+	if _, err := config.Build(); err != nil {
+		t.Error(err)
+	}
+}
@@ -12,12 +12,24 @@ var CmdAPI = &base.Command{
 `,
 	Commands: []*base.Command{
 		cmdRestartLogger,
+		cmdSetLogLevel,
+		cmdTrace,
+		cmdReload,
 		cmdGetStats,
 		cmdQueryStats,
 		cmdSysStats,
+		cmdResetQuota,
+		cmdListConnections,
+		cmdCloseConnections,
 		cmdAddInbounds,
 		cmdAddOutbounds,
 		cmdRemoveInbounds,
+		cmdInboundUser,
+		cmdInboundUserCount,
 		cmdRemoveOutbounds,
+		cmdAddRules,
+		cmdRemoveRules,
+		cmdListRules,
+		cmdBalancerOverride,
 	},
 }
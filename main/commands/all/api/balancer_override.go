@@ -0,0 +1,54 @@
+package api
+
+import (
+	routerService "github.com/xtls/xray-core/app/router/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdBalancerOverride = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api bo [--server=127.0.0.1:8080] -b '' [-o '']",
+	Short:       "Override the target of a balancer",
+	Long: `
+Make a balancer in Xray always pick the given outbound.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-b, -balancer
+		Tag of the balancer.
+	-o, -outbound
+		Tag of the outbound to pick. Leave it empty to clear the override.
+Example:
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -b "balancer" -o "proxy"
+`,
+	Run: executeBalancerOverride,
+}
+
+func executeBalancerOverride(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	var balancer, target string
+	cmd.Flag.StringVar(&balancer, "b", "", "")
+	cmd.Flag.StringVar(&balancer, "balancer", "", "")
+	cmd.Flag.StringVar(&target, "o", "", "")
+	cmd.Flag.StringVar(&target, "outbound", "", "")
+	cmd.Flag.Parse(args)
+
+	if balancer == "" {
+		base.Fatalf("no balancer specified")
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	resp, err := client.OverrideBalancerTarget(ctx, &routerService.OverrideBalancerTargetRequest{
+		BalancerTag: balancer,
+		Target:      target,
+	})
+	if err != nil {
+		base.Fatalf("failed to override balancer: %s", err)
+	}
+	showJSONResponse(resp)
+}
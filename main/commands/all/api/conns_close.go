@@ -0,0 +1,63 @@
+package api
+
+import (
+	"strconv"
+
+	statsService "github.com/xtls/xray-core/app/stats/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdCloseConnections = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api rmconn [--server=127.0.0.1:8080] [-inbound ''] [-email ''] [<id>]...",
+	Short:       "Close active connections",
+	Long: `
+Close active connections of Xray by ID, inbound or user. Requires stats to be enabled.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-inbound
+		Close connections of the inbound.
+	-email
+		Close connections of the user.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 12 13
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -email "user@example.com"
+`,
+	Run: executeCloseConnections,
+}
+
+func executeCloseConnections(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	inbound := cmd.Flag.String("inbound", "", "")
+	email := cmd.Flag.String("email", "", "")
+	cmd.Flag.Parse(args)
+
+	ids := make([]uint64, 0, cmd.Flag.NArg())
+	for _, arg := range cmd.Flag.Args() {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			base.Fatalf("invalid connection id %s: %s", arg, err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 && *inbound == "" && *email == "" {
+		base.Fatalf("no connection specified")
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := statsService.NewStatsServiceClient(conn)
+	resp, err := client.CloseConnections(ctx, &statsService.CloseConnectionsRequest{
+		Ids:        ids,
+		InboundTag: *inbound,
+		Email:      *email,
+	})
+	if err != nil {
+		base.Fatalf("failed to close connections: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	statsService "github.com/xtls/xray-core/app/stats/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdListConnections = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api lsconn [--server=127.0.0.1:8080] [-inbound ''] [-email '']",
+	Short:       "List active connections",
+	Long: `
+List the active connections of Xray. Requires stats to be enabled.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-inbound
+		Only list connections of the inbound.
+	-email
+		Only list connections of the user.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -email "user@example.com"
+`,
+	Run: executeListConnections,
+}
+
+func executeListConnections(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	inbound := cmd.Flag.String("inbound", "", "")
+	email := cmd.Flag.String("email", "", "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := statsService.NewStatsServiceClient(conn)
+	resp, err := client.ListConnections(ctx, &statsService.ListConnectionsRequest{
+		InboundTag: *inbound,
+		Email:      *email,
+	})
+	if err != nil {
+		base.Fatalf("failed to list connections: %s", err)
+	}
+	showJSONResponse(resp)
+}
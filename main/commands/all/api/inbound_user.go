@@ -0,0 +1,86 @@
+package api
+
+import (
+	handlerService "github.com/xtls/xray-core/app/proxyman/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdInboundUser = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api inbounduser [--server=127.0.0.1:8080] -tag '' [-email '']",
+	Short:       "List users of an inbound",
+	Long: `
+List users of an inbound in Xray.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-tag
+		Tag of the inbound.
+	-email
+		Only show the user with this email.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -tag "vless_in" -email "user@example.com"
+`,
+	Run: executeInboundUser,
+}
+
+func executeInboundUser(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	tag := cmd.Flag.String("tag", "", "")
+	email := cmd.Flag.String("email", "", "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := handlerService.NewHandlerServiceClient(conn)
+	r := &handlerService.ListInboundUsersRequest{
+		Tag:   *tag,
+		Email: *email,
+	}
+	resp, err := client.ListInboundUsers(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to list inbound users: %s", err)
+	}
+	showJSONResponse(resp)
+}
+
+var cmdInboundUserCount = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api inboundusercount [--server=127.0.0.1:8080] -tag ''",
+	Short:       "Get number of users of an inbound",
+	Long: `
+Get the number of users of an inbound in Xray.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-tag
+		Tag of the inbound.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -tag "vless_in"
+`,
+	Run: executeInboundUserCount,
+}
+
+func executeInboundUserCount(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	tag := cmd.Flag.String("tag", "", "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := handlerService.NewHandlerServiceClient(conn)
+	r := &handlerService.GetInboundUsersCountRequest{
+		Tag: *tag,
+	}
+	resp, err := client.GetInboundUsersCount(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to get inbound users count: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"strings"
+
+	logService "github.com/xtls/xray-core/app/log/command"
+	clog "github.com/xtls/xray-core/common/log"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdSetLogLevel = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api loglevel [--server=127.0.0.1:8080] -level warning",
+	Short:       "Set the error log level",
+	Long: `
+Set the error log level of Xray without restarting it.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-level
+		One of "debug", "info", "warning" and "error".
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -level debug
+`,
+	Run: executeSetLogLevel,
+}
+
+func executeSetLogLevel(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	level := cmd.Flag.String("level", "", "")
+	cmd.Flag.Parse(args)
+
+	var severity clog.Severity
+	switch strings.ToLower(*level) {
+	case "debug":
+		severity = clog.Severity_Debug
+	case "info":
+		severity = clog.Severity_Info
+	case "warning":
+		severity = clog.Severity_Warning
+	case "error":
+		severity = clog.Severity_Error
+	default:
+		base.Fatalf("invalid log level: %s", *level)
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := logService.NewLoggerServiceClient(conn)
+	resp, err := client.SetLogLevel(ctx, &logService.SetLogLevelRequest{
+		Level: severity,
+	})
+	if err != nil {
+		base.Fatalf("failed to set log level: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	logService "github.com/xtls/xray-core/app/log/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdTrace = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api trace [--server=127.0.0.1:8080] [-email ''] [-inbound ''] [-source ''] [-duration 300]",
+	Short:       "Trace sessions with debug log",
+	Long: `
+Enable debug log of the sessions matching all given conditions for a while,
+regardless of the error log level.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-email
+		Email of the user to trace.
+	-inbound
+		Tag of the inbound to trace.
+	-source
+		IP or CIDR of the source to trace.
+	-duration
+		Seconds to trace. Default 300, and at most 3600.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -email "user@example.com" -duration 600
+`,
+	Run: executeTrace,
+}
+
+func executeTrace(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	email := cmd.Flag.String("email", "", "")
+	inbound := cmd.Flag.String("inbound", "", "")
+	source := cmd.Flag.String("source", "", "")
+	duration := cmd.Flag.Uint("duration", 0, "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := logService.NewLoggerServiceClient(conn)
+	resp, err := client.Trace(ctx, &logService.TraceRequest{
+		Email:      *email,
+		InboundTag: *inbound,
+		Source:     *source,
+		Duration:   uint32(*duration),
+	})
+	if err != nil {
+		base.Fatalf("failed to trace: %s", err)
+	}
+	showJSONResponse(resp)
+}
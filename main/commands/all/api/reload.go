@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/xtls/xray-core/app/commander"
+	"github.com/xtls/xray-core/common/cmdarg"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdReload = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api reload [--server=127.0.0.1:8080] [-format=json] <c1.json> [c2.json]...",
+	Short:       "Reload config",
+	Long: `
+Apply config files to the running Xray without restarting it.
+Only the inbounds and outbounds that changed are replaced, and
+changed routing and DNS settings are applied. Other changes
+require a restart.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-format
+		Format of config files. Default "auto".
+Example:
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080 c1.json c2.json
+`,
+	Run: executeReload,
+}
+
+func executeReload(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	format := cmd.Flag.String("format", "auto", "")
+	cmd.Flag.Parse(args)
+	unnamedArgs := cmd.Flag.Args()
+	if len(unnamedArgs) == 0 {
+		fmt.Println("reading from stdin:")
+		unnamedArgs = []string{"stdin:"}
+	}
+
+	f := core.GetFormatByExtension(*format)
+	if f == "" {
+		f = "auto"
+	}
+	config, err := core.LoadConfig(f, cmdarg.Arg(unnamedArgs))
+	if err != nil {
+		base.Fatalf("failed to load config: %s", err)
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := commander.NewReloadServiceClient(conn)
+	resp, err := client.Reload(ctx, &commander.ReloadRequest{
+		Config: config,
+	})
+	if err != nil {
+		base.Fatalf("failed to reload config: %s", err)
+	}
+	showJSONResponse(resp)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+
+	routerService "github.com/xtls/xray-core/app/router/command"
+	"github.com/xtls/xray-core/infra/conf/serial"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdAddRules = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api adrules [--server=127.0.0.1:8080] [-append] <c1.json> [c2.json]...",
+	Short:       "Add routing rules",
+	Long: `
+Add routing rules and balancers to Xray.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-append
+		Append to the current rules instead of replacing them.
+Example:
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -append c1.json c2.json
+`,
+	Run: executeAddRules,
+}
+
+func executeAddRules(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	shouldAppend := cmd.Flag.Bool("append", false, "")
+	cmd.Flag.Parse(args)
+	unnamedArgs := cmd.Flag.Args()
+	if len(unnamedArgs) == 0 {
+		fmt.Println("reading from stdin:")
+		unnamedArgs = []string{"stdin:"}
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	appending := *shouldAppend
+	for _, arg := range unnamedArgs {
+		r, err := loadArg(arg)
+		if err != nil {
+			base.Fatalf("failed to load %s: %s", arg, err)
+		}
+		conf, err := serial.DecodeJSONConfig(r)
+		if err != nil {
+			base.Fatalf("failed to decode %s: %s", arg, err)
+		}
+		if conf.RouterConfig == nil {
+			base.Fatalf("no routing config found in %s", arg)
+		}
+		config, err := conf.RouterConfig.Build()
+		if err != nil {
+			base.Fatalf("failed to build conf: %s", err)
+		}
+		fmt.Println("adding rules from:", arg)
+		resp, err := client.AddRule(ctx, &routerService.AddRuleRequest{
+			Config:       config,
+			ShouldAppend: appending,
+		})
+		if err != nil {
+			base.Fatalf("failed to add rules: %s", err)
+		}
+		showJSONResponse(resp)
+		// rules of the following files always go after the previous ones
+		appending = true
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+
+	routerService "github.com/xtls/xray-core/app/router/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdRemoveRules = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api rmrules [--server=127.0.0.1:8080] <ruleTag> [ruleTag]...",
+	Short:       "Remove routing rules",
+	Long: `
+Remove routing rules from Xray by their rule tags.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+Example:
+    {{.Exec}} {{.LongName}} --server=127.0.0.1:8080 "rule tag"
+`,
+	Run: executeRemoveRules,
+}
+
+func executeRemoveRules(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+	tags := cmd.Flag.Args()
+	if len(tags) == 0 {
+		base.Fatalf("no rule to remove")
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := routerService.NewRoutingServiceClient(conn)
+	for _, tag := range tags {
+		fmt.Println("removing:", tag)
+		resp, err := client.RemoveRule(ctx, &routerService.RemoveRuleRequest{
+			RuleTag: tag,
+		})
+		if err != nil {
+			base.Fatalf("failed to remove rule: %s", err)
+		}
+		showJSONResponse(resp)
+	}
+}
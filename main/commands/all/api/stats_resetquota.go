@@ -0,0 +1,44 @@
+package api
+
+import (
+	statsService "github.com/xtls/xray-core/app/stats/command"
+	"github.com/xtls/xray-core/main/commands/base"
+)
+
+var cmdResetQuota = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api resetquota [--server=127.0.0.1:8080] -email ''",
+	Short:       "Reset traffic quota of a user",
+	Long: `
+Reset the traffic counters of a user, which restores its traffic quota.
+Arguments:
+	-s, -server 
+		The API server address. Default 127.0.0.1:8080
+	-t, -timeout
+		Timeout seconds to call API. Default 3
+	-email
+		Email of the user.
+Example:
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -email "user@example.com"
+`,
+	Run: executeResetQuota,
+}
+
+func executeResetQuota(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	email := cmd.Flag.String("email", "", "")
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := statsService.NewStatsServiceClient(conn)
+	r := &statsService.ResetUserQuotaRequest{
+		Email: *email,
+	}
+	resp, err := client.ResetUserQuota(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to reset quota: %s", err)
+	}
+	showJSONResponse(resp)
+}
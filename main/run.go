@@ -92,8 +92,15 @@ func executeRun(cmd *base.Command, args []string) {
 
 	{
 		osSignals := make(chan os.Signal, 1)
-		signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM)
-		<-osSignals
+		signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		for sig := range osSignals {
+			if sig != syscall.SIGHUP {
+				break
+			}
+			if err := reloadXray(server); err != nil {
+				newError("failed to reload config").Base(err).AtError().WriteToLog()
+			}
+		}
 	}
 }
 
@@ -123,7 +130,8 @@ func getRegepxByFormat() string {
 	}
 }
 
-func readConfDir(dirPath string) {
+func readConfDir(dirPath string) cmdarg.Arg {
+	var files cmdarg.Arg
 	confs, err := os.ReadDir(dirPath)
 	if err != nil {
 		log.Fatalln(err)
@@ -134,22 +142,25 @@ func readConfDir(dirPath string) {
 			log.Fatalln(err)
 		}
 		if matched {
-			configFiles.Set(path.Join(dirPath, f.Name()))
+			files.Set(path.Join(dirPath, f.Name()))
 		}
 	}
+	return files
 }
 
 func getConfigFilePath() cmdarg.Arg {
+	// Keep configFiles untouched, as the config dir is read again on reload.
+	files := append(cmdarg.Arg{}, configFiles...)
 	if dirExists(configDir) {
 		log.Println("Using confdir from arg:", configDir)
-		readConfDir(configDir)
+		files = append(files, readConfDir(configDir)...)
 	} else if envConfDir := platform.GetConfDirPath(); dirExists(envConfDir) {
 		log.Println("Using confdir from env:", envConfDir)
-		readConfDir(envConfDir)
+		files = append(files, readConfDir(envConfDir)...)
 	}
 
-	if len(configFiles) > 0 {
-		return configFiles
+	if len(files) > 0 {
+		return files
 	}
 
 	if workingDir, err := os.Getwd(); err == nil {
@@ -194,3 +205,24 @@ func startXray() (core.Server, error) {
 
 	return server, nil
 }
+
+// reloadXray loads the config files again and applies them to the running server.
+func reloadXray(server core.Server) error {
+	configFiles := getConfigFilePath()
+	for _, file := range configFiles {
+		if file == "stdin:" {
+			return newError("config from STDIN can't be reloaded")
+		}
+	}
+
+	c, err := core.LoadConfig(getConfigFormat(), configFiles)
+	if err != nil {
+		return newError("failed to load config files: [", configFiles.String(), "]").Base(err)
+	}
+
+	instance, ok := server.(*core.Instance)
+	if !ok {
+		return newError("server doesn't support reloading")
+	}
+	return instance.Reload(c)
+}
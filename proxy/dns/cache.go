@@ -0,0 +1,189 @@
+package dns
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/task"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// maxCacheTTL is the maximum time that forwarded answers are cached for.
+	maxCacheTTL = 3600
+	// negativeCacheTTL is the time that negative answers without SOA record are cached for.
+	negativeCacheTTL = 60
+	// maxCacheEntries is the maximum number of cached answers.
+	maxCacheEntries = 4096
+)
+
+type cacheKey struct {
+	name  string
+	qType dnsmessage.Type
+}
+
+type cachedAnswer struct {
+	msg     dnsmessage.Message
+	created time.Time
+	expire  time.Time
+	// element is the element of the answer in the LRU list.
+	element *list.Element
+}
+
+// answerCache caches answers of forwarded queries by domain and query type, and evicts the least
+// recently used ones when full.
+type answerCache struct {
+	sync.Mutex
+	answers map[cacheKey]*cachedAnswer
+	lru     *list.List
+	cleanup *task.Periodic
+}
+
+func newAnswerCache() *answerCache {
+	c := &answerCache{
+		answers: make(map[cacheKey]*cachedAnswer),
+		lru:     list.New(),
+	}
+	c.cleanup = &task.Periodic{
+		Interval: time.Minute,
+		Execute:  c.Cleanup,
+	}
+	return c
+}
+
+func newCacheKey(q dnsmessage.Question) cacheKey {
+	return cacheKey{
+		name:  strings.ToLower(q.Name.String()),
+		qType: q.Type,
+	}
+}
+
+// Cleanup clears expired answers from cache
+func (c *answerCache) Cleanup() error {
+	now := time.Now()
+	c.Lock()
+	defer c.Unlock()
+
+	if len(c.answers) == 0 {
+		return newError("nothing to do. stopping...")
+	}
+
+	for key, answer := range c.answers {
+		if answer.expire.Before(now) {
+			c.lru.Remove(answer.element)
+			delete(c.answers, key)
+		}
+	}
+
+	if len(c.answers) == 0 {
+		c.answers = make(map[cacheKey]*cachedAnswer)
+	}
+
+	return nil
+}
+
+// put caches the response. Only successful and NXDOMAIN responses are cached, for the minimum
+// TTL of their records. Negative responses, which are NXDOMAIN or have no answers, are cached for
+// the TTL of their SOA record as in RFC 2308, or negativeCacheTTL without one.
+func (c *answerCache) put(b []byte) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(b); err != nil {
+		return
+	}
+	if !msg.Response || msg.Truncated || len(msg.Questions) != 1 {
+		return
+	}
+	if msg.RCode != dnsmessage.RCodeSuccess && msg.RCode != dnsmessage.RCodeNameError {
+		return
+	}
+
+	ttl := answerTTL(&msg)
+	if ttl == 0 {
+		return
+	}
+
+	now := time.Now()
+	key := newCacheKey(msg.Questions[0])
+	answer := &cachedAnswer{
+		msg:     msg,
+		created: now,
+		expire:  now.Add(time.Duration(ttl) * time.Second),
+	}
+	c.Lock()
+	if old, found := c.answers[key]; found {
+		c.lru.Remove(old.element)
+	} else if len(c.answers) >= maxCacheEntries {
+		oldest := c.lru.Remove(c.lru.Back()).(cacheKey)
+		delete(c.answers, oldest)
+	}
+	answer.element = c.lru.PushFront(key)
+	c.answers[key] = answer
+	c.Unlock()
+	common.Must(c.cleanup.Start())
+}
+
+// answerTTL returns the time in seconds that msg can be cached for.
+func answerTTL(msg *dnsmessage.Message) uint32 {
+	ttl := uint32(maxCacheTTL)
+	if msg.RCode == dnsmessage.RCodeSuccess && len(msg.Answers) > 0 {
+		for _, resources := range [][]dnsmessage.Resource{msg.Answers, msg.Authorities} {
+			for _, r := range resources {
+				if r.Header.TTL < ttl {
+					ttl = r.Header.TTL
+				}
+			}
+		}
+		return ttl
+	}
+
+	for _, r := range msg.Authorities {
+		if soa, ok := r.Body.(*dnsmessage.SOAResource); ok {
+			if r.Header.TTL < ttl {
+				ttl = r.Header.TTL
+			}
+			if soa.MinTTL < ttl {
+				ttl = soa.MinTTL
+			}
+			return ttl
+		}
+	}
+	return negativeCacheTTL
+}
+
+// get returns the cached response of the question with the given ID, whose TTLs are reduced by
+// the time it has been cached.
+func (c *answerCache) get(id uint16, q dnsmessage.Question) ([]byte, bool) {
+	now := time.Now()
+	c.Lock()
+	answer, found := c.answers[newCacheKey(q)]
+	if found {
+		c.lru.MoveToFront(answer.element)
+	}
+	c.Unlock()
+	if !found || !answer.expire.After(now) {
+		return nil, false
+	}
+
+	elapsed := uint32(now.Sub(answer.created) / time.Second)
+	msg := answer.msg
+	msg.ID = id
+	msg.Answers = reduceTTL(msg.Answers, elapsed)
+	msg.Authorities = reduceTTL(msg.Authorities, elapsed)
+	b, err := msg.Pack()
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+func reduceTTL(resources []dnsmessage.Resource, elapsed uint32) []dnsmessage.Resource {
+	reduced := make([]dnsmessage.Resource, len(resources))
+	copy(reduced, resources)
+	for i := range reduced {
+		reduced[i].Header.TTL -= elapsed
+	}
+	return reduced
+}
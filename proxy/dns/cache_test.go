@@ -0,0 +1,105 @@
+package dns
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func packResponse(name string, rcode dnsmessage.RCode, answers, authorities []dnsmessage.Resource) []byte {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{Response: true, RCode: rcode},
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName(name),
+			Type:  dnsmessage.TypeA,
+			Class: dnsmessage.ClassINET,
+		}},
+		Answers:     answers,
+		Authorities: authorities,
+	}
+	b, err := msg.Pack()
+	common.Must(err)
+	return b
+}
+
+func TestAnswerTTL(t *testing.T) {
+	soa := dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{
+			Name:  dnsmessage.MustNewName("example.com."),
+			Type:  dnsmessage.TypeSOA,
+			Class: dnsmessage.ClassINET,
+			TTL:   600,
+		},
+		Body: &dnsmessage.SOAResource{
+			NS:     dnsmessage.MustNewName("ns.example.com."),
+			MBox:   dnsmessage.MustNewName("admin.example.com."),
+			MinTTL: 300,
+		},
+	}
+	a := dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{
+			Name:  dnsmessage.MustNewName("example.com."),
+			Type:  dnsmessage.TypeA,
+			Class: dnsmessage.ClassINET,
+			TTL:   120,
+		},
+		Body: &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}},
+	}
+
+	cases := []struct {
+		name        string
+		rcode       dnsmessage.RCode
+		answers     []dnsmessage.Resource
+		authorities []dnsmessage.Resource
+		ttl         uint32
+	}{
+		{"answer", dnsmessage.RCodeSuccess, []dnsmessage.Resource{a}, nil, 120},
+		{"nxdomain with soa", dnsmessage.RCodeNameError, nil, []dnsmessage.Resource{soa}, 300},
+		{"nodata with soa", dnsmessage.RCodeSuccess, nil, []dnsmessage.Resource{soa}, 300},
+		{"nxdomain without soa", dnsmessage.RCodeNameError, nil, nil, negativeCacheTTL},
+		{"nodata without soa", dnsmessage.RCodeSuccess, nil, nil, negativeCacheTTL},
+	}
+	for _, tc := range cases {
+		var msg dnsmessage.Message
+		common.Must(msg.Unpack(packResponse("example.com.", tc.rcode, tc.answers, tc.authorities)))
+		if ttl := answerTTL(&msg); ttl != tc.ttl {
+			t.Error(tc.name, ": expect TTL ", tc.ttl, ", but got ", ttl)
+		}
+	}
+}
+
+func TestAnswerCacheEviction(t *testing.T) {
+	c := newAnswerCache()
+	defer c.cleanup.Close()
+
+	question := func(i int) dnsmessage.Question {
+		return dnsmessage.Question{
+			Name:  dnsmessage.MustNewName("test" + strconv.Itoa(i) + ".example.com."),
+			Type:  dnsmessage.TypeA,
+			Class: dnsmessage.ClassINET,
+		}
+	}
+	for i := 0; i < maxCacheEntries; i++ {
+		c.put(packResponse(question(i).Name.String(), dnsmessage.RCodeNameError, nil, nil))
+	}
+	// Recently used answers are kept.
+	if _, found := c.get(1, question(0)); !found {
+		t.Fatal("expect the first answer to be cached")
+	}
+	c.put(packResponse(question(maxCacheEntries).Name.String(), dnsmessage.RCodeNameError, nil, nil))
+
+	if len(c.answers) != maxCacheEntries {
+		t.Error("expect ", maxCacheEntries, " answers, but got ", len(c.answers))
+	}
+	if _, found := c.get(1, question(maxCacheEntries)); !found {
+		t.Error("expect the new answer to be cached")
+	}
+	if _, found := c.get(1, question(0)); !found {
+		t.Error("expect the recently used answer to be kept")
+	}
+	if _, found := c.get(1, question(1)); found {
+		t.Error("expect the least recently used answer to be evicted")
+	}
+}
@@ -3,6 +3,8 @@ package dns
 import (
 	"context"
 	"io"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,6 +28,9 @@ func init() {
 	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
 		h := new(Handler)
 		if err := core.RequireFeatures(ctx, func(dnsClient dns.Client, policyManager policy.Manager) error {
+			if fdns := core.MustFromContext(ctx).GetFeature((*dns.FakeDNSEngine)(nil)); fdns != nil {
+				h.fdns = fdns.(dns.FakeDNSEngine)
+			}
 			return h.Init(config.(*Config), dnsClient, policyManager)
 		}); err != nil {
 			return nil, err
@@ -38,11 +43,21 @@ type ownLinkVerifier interface {
 	IsOwnLink(ctx context.Context) bool
 }
 
+// Types of DNS records that are not defined in dnsmessage.
+const (
+	typeSVCB  dnsmessage.Type = 64
+	typeHTTPS dnsmessage.Type = 65
+)
+
 type Handler struct {
 	client          dns.Client
 	ownLinkVerifier ownLinkVerifier
+	hosts           dns.HostsLookup
+	fdns            dns.FakeDNSEngine
 	server          net.Destination
 	timeout         time.Duration
+	actions         map[dnsmessage.Type]QueryAction
+	cache           *answerCache
 }
 
 func (h *Handler) Init(config *Config, dnsClient dns.Client, policyManager policy.Manager) error {
@@ -52,10 +67,27 @@ func (h *Handler) Init(config *Config, dnsClient dns.Client, policyManager polic
 	if v, ok := dnsClient.(ownLinkVerifier); ok {
 		h.ownLinkVerifier = v
 	}
+	if v, ok := dnsClient.(dns.HostsLookup); ok {
+		h.hosts = v
+	}
 
 	if config.Server != nil {
 		h.server = config.Server.AsDestination()
 	}
+
+	h.actions = make(map[dnsmessage.Type]QueryAction)
+	for _, rule := range config.QueryTypeRule {
+		for _, t := range rule.Type {
+			if t > 0xffff {
+				return newError("invalid DNS query type: ", t)
+			}
+			h.actions[dnsmessage.Type(t)] = rule.Action
+		}
+	}
+
+	if !config.DisableCache {
+		h.cache = newAnswerCache()
+	}
 	return nil
 }
 
@@ -63,7 +95,7 @@ func (h *Handler) isOwnLink(ctx context.Context) bool {
 	return h.ownLinkVerifier != nil && h.ownLinkVerifier.IsOwnLink(ctx)
 }
 
-func parseIPQuery(b []byte) (r bool, domain string, id uint16, qType dnsmessage.Type) {
+func parseQuery(b []byte) (r bool, id uint16, q dnsmessage.Question) {
 	var parser dnsmessage.Parser
 	header, err := parser.Start(b)
 	if err != nil {
@@ -72,17 +104,12 @@ func parseIPQuery(b []byte) (r bool, domain string, id uint16, qType dnsmessage.
 	}
 
 	id = header.ID
-	q, err := parser.Question()
+	q, err = parser.Question()
 	if err != nil {
 		newError("question").Base(err).WriteToLog()
 		return
 	}
-	qType = q.Type
-	if qType != dnsmessage.TypeA && qType != dnsmessage.TypeAAAA {
-		return
-	}
 
-	domain = q.Name.String()
 	r = true
 	return
 }
@@ -170,12 +197,9 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 
 			timer.Update()
 
-			if !h.isOwnLink(ctx) {
-				isIPQuery, domain, id, qType := parseIPQuery(b.Bytes())
-				if isIPQuery {
-					go h.handleIPQuery(id, qType, domain, writer)
-					continue
-				}
+			if !h.isOwnLink(ctx) && h.handleQuery(ctx, b.Bytes(), writer) {
+				b.Release()
+				continue
 			}
 
 			if err := connWriter.WriteMessage(b); err != nil {
@@ -197,6 +221,10 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 
 			timer.Update()
 
+			if h.cache != nil {
+				h.cacheResponse(b.Bytes())
+			}
+
 			if err := writer.WriteMessage(b); err != nil {
 				return err
 			}
@@ -210,7 +238,158 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, d internet.
 	return nil
 }
 
-func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string, writer dns_proto.MessageWriter) {
+// handleQuery answers the query by its action, hosts, FakeDNS or cache, and returns whether it
+// is handled. Queries that are not handled are forwarded to the server.
+func (h *Handler) handleQuery(ctx context.Context, b []byte, writer dns_proto.MessageWriter) bool {
+	isQuery, id, q := parseQuery(b)
+	if !isQuery {
+		return false
+	}
+
+	switch h.actions[q.Type] {
+	case QueryAction_Forward:
+		return false
+	case QueryAction_Drop:
+		newError("drop ", q.Type, " query for ", q.Name).AtDebug().WriteToLog()
+		return true
+	case QueryAction_Empty:
+		writeResponse(writer, id, q, dnsmessage.RCodeSuccess, nil)
+		return true
+	case QueryAction_Refuse:
+		writeResponse(writer, id, q, dnsmessage.RCodeRefused, nil)
+		return true
+	}
+
+	switch q.Type {
+	case dnsmessage.TypeA, dnsmessage.TypeAAAA:
+		go h.handleIPQuery(ctx, id, q.Type, q.Name.String(), writer)
+		return true
+	case dnsmessage.TypeCNAME, typeSVCB, typeHTTPS:
+		if h.handleHostsQuery(id, q, writer) {
+			return true
+		}
+	case dnsmessage.TypePTR:
+		if h.handleFakeDNSQuery(id, q, writer) {
+			return true
+		}
+	}
+
+	if h.cache != nil {
+		if b, found := h.cache.get(id, q); found {
+			newError("cache HIT ", q.Type, " query for ", q.Name).AtDebug().WriteToLog()
+			if err := writer.WriteMessage(buf.FromBytes(b)); err != nil {
+				newError("write cached answer").Base(err).WriteToLog()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// cacheResponse caches the response of a forwarded query, if its type is handled by this handler.
+func (h *Handler) cacheResponse(b []byte) {
+	isQuery, _, q := parseQuery(b)
+	if !isQuery || h.actions[q.Type] != QueryAction_Default {
+		return
+	}
+	if q.Type == dnsmessage.TypeA || q.Type == dnsmessage.TypeAAAA {
+		return
+	}
+	h.cache.put(b)
+}
+
+// handleHostsQuery answers CNAME, SVCB and HTTPS queries of the domains in hosts. Domains replaced
+// by hosts are answered with CNAME records, and other records are left empty, so that clients use
+// the A and AAAA records of hosts.
+func (h *Handler) handleHostsQuery(id uint16, q dnsmessage.Question, writer dns_proto.MessageWriter) bool {
+	if h.hosts == nil {
+		return false
+	}
+	addr := h.hosts.LookupHosts(q.Name.String())
+	if addr == nil {
+		return false
+	}
+
+	if q.Type == dnsmessage.TypeCNAME && (*addr).Family().IsDomain() {
+		target, err := dnsmessage.NewName(strings.TrimSuffix((*addr).Domain(), ".") + ".")
+		if err != nil {
+			newError("invalid domain in hosts: ", (*addr).Domain()).Base(err).WriteToLog()
+			return false
+		}
+		writeResponse(writer, id, q, dnsmessage.RCodeSuccess, func(builder *dnsmessage.Builder, header dnsmessage.ResourceHeader) error {
+			return builder.CNAMEResource(header, dnsmessage.CNAMEResource{CNAME: target})
+		})
+		return true
+	}
+
+	writeResponse(writer, id, q, dnsmessage.RCodeSuccess, nil)
+	return true
+}
+
+// handleFakeDNSQuery answers PTR queries of fake IPs with their domains.
+func (h *Handler) handleFakeDNSQuery(id uint16, q dnsmessage.Question, writer dns_proto.MessageWriter) bool {
+	fkr0, ok := h.fdns.(dns.FakeDNSEngineRev0)
+	if !ok {
+		return false
+	}
+	ip := parsePTRName(q.Name.String())
+	if ip == nil || !fkr0.IsIPInIPPool(net.IPAddress(ip)) {
+		return false
+	}
+
+	domain := fkr0.GetDomainFromFakeDNS(net.IPAddress(ip))
+	if len(domain) == 0 {
+		writeResponse(writer, id, q, dnsmessage.RCodeNameError, nil)
+		return true
+	}
+	target, err := dnsmessage.NewName(strings.TrimSuffix(domain, ".") + ".")
+	if err != nil {
+		newError("invalid domain of fake IP ", ip).Base(err).WriteToLog()
+		return false
+	}
+	writeResponse(writer, id, q, dnsmessage.RCodeSuccess, func(builder *dnsmessage.Builder, header dnsmessage.ResourceHeader) error {
+		return builder.PTRResource(header, dnsmessage.PTRResource{PTR: target})
+	})
+	return true
+}
+
+// parsePTRName returns the IP of a reverse lookup domain in in-addr.arpa or ip6.arpa.
+func parsePTRName(name string) net.IP {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	switch {
+	case strings.HasSuffix(name, ".in-addr.arpa"):
+		labels := strings.Split(strings.TrimSuffix(name, ".in-addr.arpa"), ".")
+		if len(labels) != net.IPv4len {
+			return nil
+		}
+		ip := make(net.IP, net.IPv4len)
+		for i, label := range labels {
+			v, err := strconv.ParseUint(label, 10, 8)
+			if err != nil {
+				return nil
+			}
+			ip[net.IPv4len-1-i] = byte(v)
+		}
+		return ip
+	case strings.HasSuffix(name, ".ip6.arpa"):
+		labels := strings.Split(strings.TrimSuffix(name, ".ip6.arpa"), ".")
+		if len(labels) != net.IPv6len*2 {
+			return nil
+		}
+		ip := make(net.IP, net.IPv6len)
+		for i, label := range labels {
+			v, err := strconv.ParseUint(label, 16, 4)
+			if err != nil || len(label) != 1 {
+				return nil
+			}
+			ip[net.IPv6len-1-i/2] |= byte(v) << (4 * (i % 2))
+		}
+		return ip
+	}
+	return nil
+}
+
+func (h *Handler) handleIPQuery(ctx context.Context, id uint16, qType dnsmessage.Type, domain string, writer dns_proto.MessageWriter) {
 	var ips []net.IP
 	var err error
 
@@ -218,13 +397,13 @@ func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string,
 
 	switch qType {
 	case dnsmessage.TypeA:
-		ips, err = h.client.LookupIP(domain, dns.IPOption{
+		ips, err = dns.LookupIPWithContext(ctx, h.client, domain, dns.IPOption{
 			IPv4Enable: true,
 			IPv6Enable: false,
 			FakeEnable: true,
 		})
 	case dnsmessage.TypeAAAA:
-		ips, err = h.client.LookupIP(domain, dns.IPOption{
+		ips, err = dns.LookupIPWithContext(ctx, h.client, domain, dns.IPOption{
 			IPv4Enable: false,
 			IPv6Enable: true,
 			FakeEnable: true,
@@ -248,11 +427,35 @@ func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string,
 		}
 	}
 
+	q := dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(domain),
+		Class: dnsmessage.ClassINET,
+		Type:  qType,
+	}
+	writeResponse(writer, id, q, dnsmessage.RCode(rcode), func(builder *dnsmessage.Builder, rHeader dnsmessage.ResourceHeader) error {
+		rHeader.TTL = ttl
+		for _, ip := range ips {
+			if len(ip) == net.IPv4len {
+				var r dnsmessage.AResource
+				copy(r.A[:], ip)
+				common.Must(builder.AResource(rHeader, r))
+			} else {
+				var r dnsmessage.AAAAResource
+				copy(r.AAAA[:], ip)
+				common.Must(builder.AAAAResource(rHeader, r))
+			}
+		}
+		return nil
+	})
+}
+
+// writeResponse answers the query with the given rcode. Records are added by answer if it's not nil.
+func writeResponse(writer dns_proto.MessageWriter, id uint16, q dnsmessage.Question, rcode dnsmessage.RCode, answer func(*dnsmessage.Builder, dnsmessage.ResourceHeader) error) {
 	b := buf.New()
 	rawBytes := b.Extend(buf.Size)
 	builder := dnsmessage.NewBuilder(rawBytes[:0], dnsmessage.Header{
 		ID:                 id,
-		RCode:              dnsmessage.RCode(rcode),
+		RCode:              rcode,
 		RecursionAvailable: true,
 		RecursionDesired:   true,
 		Response:           true,
@@ -260,23 +463,15 @@ func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string,
 	})
 	builder.EnableCompression()
 	common.Must(builder.StartQuestions())
-	common.Must(builder.Question(dnsmessage.Question{
-		Name:  dnsmessage.MustNewName(domain),
-		Class: dnsmessage.ClassINET,
-		Type:  qType,
-	}))
+	common.Must(builder.Question(q))
 	common.Must(builder.StartAnswers())
 
-	rHeader := dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(domain), Class: dnsmessage.ClassINET, TTL: ttl}
-	for _, ip := range ips {
-		if len(ip) == net.IPv4len {
-			var r dnsmessage.AResource
-			copy(r.A[:], ip)
-			common.Must(builder.AResource(rHeader, r))
-		} else {
-			var r dnsmessage.AAAAResource
-			copy(r.AAAA[:], ip)
-			common.Must(builder.AAAAResource(rHeader, r))
+	if answer != nil {
+		rHeader := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: 600}
+		if err := answer(&builder, rHeader); err != nil {
+			newError("build answer").Base(err).WriteToLog()
+			b.Release()
+			return
 		}
 	}
 	msgBytes, err := builder.Finish()
@@ -288,7 +483,7 @@ func (h *Handler) handleIPQuery(id uint16, qType dnsmessage.Type, domain string,
 	b.Resize(0, int32(len(msgBytes)))
 
 	if err := writer.WriteMessage(b); err != nil {
-		newError("write IP answer").Base(err).WriteToLog()
+		newError("write answer").Base(err).WriteToLog()
 	}
 }
 
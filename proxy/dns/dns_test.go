@@ -2,6 +2,7 @@ package dns_test
 
 import (
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -25,6 +26,9 @@ import (
 
 type staticHandler struct{}
 
+// txtQueries counts TXT queries received by staticHandler.
+var txtQueries int32
+
 func (*staticHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	ans := new(dns.Msg)
 	ans.Id = r.Id
@@ -68,6 +72,13 @@ func (*staticHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 
 		case q.Name == "notexist.google.com." && q.Qtype == dns.TypeAAAA:
 			ans.MsgHdr.Rcode = dns.RcodeNameError
+
+		case q.Name == "google.com." && q.Qtype == dns.TypeTXT:
+			atomic.AddInt32(&txtQueries, 1)
+			ans.SetReply(r)
+			rr, err := dns.NewRR("google.com. 300 IN TXT \"hello\"")
+			common.Must(err)
+			ans.Answer = append(ans.Answer, rr)
 		}
 	}
 	w.WriteMsg(ans)
@@ -366,3 +377,138 @@ func TestUDP2TCPDNSTunnel(t *testing.T) {
 		t.Error(r)
 	}
 }
+
+func TestDNSQueryTypes(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+		UDPSize: 1200,
+	}
+	defer dnsServer.Shutdown()
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	serverPort := udp.PickPort()
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dnsapp.Config{
+				NameServers: []*net.Endpoint{
+					{
+						Network: net.Network_UDP,
+						Address: &net.IPOrDomain{
+							Address: &net.IPOrDomain_Ip{
+								Ip: []byte{127, 0, 0, 1},
+							},
+						},
+						Port: uint32(port),
+					},
+				},
+				StaticHosts: []*dnsapp.Config_HostMapping{
+					{
+						Type:          dnsapp.DomainMatchingType_Full,
+						Domain:        "alias.google.com",
+						ProxiedDomain: "google.com",
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Inbound: []*core.InboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+					Address:  net.NewIPOrDomain(net.LocalHostIP),
+					Port:     uint32(port),
+					Networks: []net.Network{net.Network_UDP},
+				}),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortList: &net.PortList{Range: []*net.PortRange{net.SinglePortRange(serverPort)}},
+					Listen:   net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.Config{
+					QueryTypeRule: []*dns_proxy.QueryTypeRule{
+						{
+							Type:   []uint32{uint32(dns.TypeAAAA)},
+							Action: dns_proxy.QueryAction_Empty,
+						},
+						{
+							Type:   []uint32{uint32(dns.TypeHTTPS)},
+							Action: dns_proxy.QueryAction_Refuse,
+						},
+					},
+				}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+	common.Must(v.Start())
+	defer v.Close()
+
+	query := func(name string, qType uint16) *dns.Msg {
+		m1 := new(dns.Msg)
+		m1.Id = dns.Id()
+		m1.RecursionDesired = true
+		m1.Question = make([]dns.Question, 1)
+		m1.Question[0] = dns.Question{Name: name, Qtype: qType, Qclass: dns.ClassINET}
+
+		c := new(dns.Client)
+		in, _, err := c.Exchange(m1, "127.0.0.1:"+strconv.Itoa(int(serverPort)))
+		common.Must(err)
+		if in.Id != m1.Id {
+			t.Error("unexpected ID ", in.Id, ", expected ", m1.Id)
+		}
+		return in
+	}
+
+	atomic.StoreInt32(&txtQueries, 0)
+	for i := 0; i < 2; i++ {
+		in := query("google.com.", dns.TypeTXT)
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+		rr, ok := in.Answer[0].(*dns.TXT)
+		if !ok {
+			t.Fatal("not TXT record")
+		}
+		if r := cmp.Diff(rr.Txt, []string{"hello"}); r != "" {
+			t.Error(r)
+		}
+	}
+	if n := atomic.LoadInt32(&txtQueries); n != 1 {
+		t.Error("expect TXT answer to be cached, but got ", n, " queries")
+	}
+
+	if in := query("ipv6.google.com.", dns.TypeAAAA); in.Rcode != dns.RcodeSuccess || len(in.Answer) != 0 {
+		t.Error("expect empty AAAA answer, but got ", in)
+	}
+
+	if in := query("google.com.", dns.TypeHTTPS); in.Rcode != dns.RcodeRefused {
+		t.Error("expected Refused, but got ", in.Rcode)
+	}
+
+	{
+		in := query("alias.google.com.", dns.TypeCNAME)
+		if len(in.Answer) != 1 {
+			t.Fatal("len(answer): ", len(in.Answer))
+		}
+		rr, ok := in.Answer[0].(*dns.CNAME)
+		if !ok {
+			t.Fatal("not CNAME record")
+		}
+		if rr.Target != "google.com." {
+			t.Error("unexpected CNAME target ", rr.Target)
+		}
+	}
+}
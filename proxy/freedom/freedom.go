@@ -79,7 +79,7 @@ func (h *Handler) resolveIP(ctx context.Context, domain string, localAddr net.Ad
 		}
 	}
 
-	ips, err := h.dns.LookupIP(domain, option)
+	ips, err := dns.LookupIPWithContext(ctx, h.dns, domain, option)
 	if err != nil {
 		newError("failed to get IP address for domain ", domain).Base(err).WriteToLog(session.ExportIDToError(ctx))
 	}
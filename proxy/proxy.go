@@ -38,6 +38,12 @@ type UserManager interface {
 
 	// RemoveUser removes a user by email.
 	RemoveUser(context.Context, string) error
+
+	// GetUsers returns all users.
+	GetUsers(context.Context) []*protocol.MemoryUser
+
+	// GetUsersCount returns the number of users.
+	GetUsersCount(context.Context) int64
 }
 
 type GetInbound interface {
@@ -8,6 +8,7 @@ import (
 	"crypto/sha1"
 	"io"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/antireplay"
 	"github.com/xtls/xray-core/common/buf"
@@ -19,8 +20,10 @@ import (
 
 // MemoryAccount is an account type converted from Account.
 type MemoryAccount struct {
-	Cipher Cipher
-	Key    []byte
+	Cipher     Cipher
+	CipherType CipherType
+	Password   string
+	Key        []byte
 
 	replayFilter antireplay.GeneralizedReplayFilter
 }
@@ -35,6 +38,15 @@ func (a *MemoryAccount) Equals(another protocol.Account) bool {
 	return false
 }
 
+// ToProto implements protocol.HasProto.
+func (a *MemoryAccount) ToProto() proto.Message {
+	return &Account{
+		Password:   a.Password,
+		CipherType: a.CipherType,
+		IvCheck:    a.replayFilter != nil,
+	}
+}
+
 func (a *MemoryAccount) CheckIV(iv []byte) error {
 	if a.replayFilter == nil {
 		return nil
@@ -105,8 +117,10 @@ func (a *Account) AsAccount() (protocol.Account, error) {
 		return nil, newError("failed to get cipher").Base(err)
 	}
 	return &MemoryAccount{
-		Cipher: Cipher,
-		Key:    passwordToCipherKey([]byte(a.Password), Cipher.KeySize()),
+		Cipher:     Cipher,
+		CipherType: a.CipherType,
+		Password:   a.Password,
+		Key:        passwordToCipherKey([]byte(a.Password), Cipher.KeySize()),
 		replayFilter: func() antireplay.GeneralizedReplayFilter {
 			if a.IvCheck {
 				return antireplay.NewBloomRing()
@@ -73,6 +73,24 @@ func (v *Validator) Del(email string) error {
 	return nil
 }
 
+// GetAll returns all users.
+func (v *Validator) GetAll() []*protocol.MemoryUser {
+	v.RLock()
+	defer v.RUnlock()
+
+	users := make([]*protocol.MemoryUser, len(v.users))
+	copy(users, v.users)
+	return users
+}
+
+// GetCount returns the number of users.
+func (v *Validator) GetCount() int64 {
+	v.RLock()
+	defer v.RUnlock()
+
+	return int64(len(v.users))
+}
+
 // Get a Shadowsocks user.
 func (v *Validator) Get(bs []byte, command protocol.RequestCommand) (u *protocol.MemoryUser, aead cipher.AEAD, ret []byte, ivLen int32, err error) {
 	v.RLock()
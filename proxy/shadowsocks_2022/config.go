@@ -1,6 +1,7 @@
 package shadowsocks_2022
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/common/protocol"
 )
 
@@ -27,3 +28,12 @@ func (a *MemoryAccount) Equals(another protocol.Account) bool {
 	}
 	return false
 }
+
+// ToProto implements protocol.HasProto.
+func (a *MemoryAccount) ToProto() proto.Message {
+	return &User{
+		Key:   a.Key,
+		Email: a.Email,
+		Level: a.Level,
+	}
+}
@@ -148,6 +148,34 @@ func (i *MultiUserInbound) RemoveUser(ctx context.Context, email string) error {
 	return nil
 }
 
+// GetUsers implements proxy.UserManager.GetUsers().
+func (i *MultiUserInbound) GetUsers(ctx context.Context) []*protocol.MemoryUser {
+	i.Lock()
+	defer i.Unlock()
+
+	users := make([]*protocol.MemoryUser, 0, len(i.users))
+	for _, u := range i.users {
+		users = append(users, &protocol.MemoryUser{
+			Email: u.Email,
+			Level: uint32(u.Level),
+			Account: &MemoryAccount{
+				Key:   u.Key,
+				Email: u.Email,
+				Level: u.Level,
+			},
+		})
+	}
+	return users
+}
+
+// GetUsersCount implements proxy.UserManager.GetUsersCount().
+func (i *MultiUserInbound) GetUsersCount(ctx context.Context) int64 {
+	i.Lock()
+	defer i.Unlock()
+
+	return int64(len(i.users))
+}
+
 func (i *MultiUserInbound) Network() []net.Network {
 	return i.networks
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	fmt "fmt"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/common"
 	"github.com/xtls/xray-core/common/protocol"
 )
@@ -35,6 +36,14 @@ func (a *MemoryAccount) Equals(another protocol.Account) bool {
 	return false
 }
 
+// ToProto implements protocol.HasProto.
+func (a *MemoryAccount) ToProto() proto.Message {
+	return &Account{
+		Password: a.Password,
+		Flow:     a.Flow,
+	}
+}
+
 func hexSha224(password string) []byte {
 	buf := make([]byte, 56)
 	hash := sha256.New224()
@@ -50,3 +50,23 @@ func (v *Validator) Get(id uuid.UUID) *protocol.MemoryUser {
 	}
 	return nil
 }
+
+// GetAll returns all users.
+func (v *Validator) GetAll() []*protocol.MemoryUser {
+	var users []*protocol.MemoryUser
+	v.users.Range(func(key, value interface{}) bool {
+		users = append(users, value.(*protocol.MemoryUser))
+		return true
+	})
+	return users
+}
+
+// GetCount returns the number of users.
+func (v *Validator) GetCount() int64 {
+	var count int64
+	v.users.Range(func(key, value interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
@@ -3,6 +3,7 @@ package vmess
 import (
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/xtls/xray-core/common/dice"
 	"github.com/xtls/xray-core/common/protocol"
 	"github.com/xtls/xray-core/common/uuid"
@@ -39,6 +40,23 @@ func (a *MemoryAccount) Equals(account protocol.Account) bool {
 	return a.ID.Equals(vmessAccount.ID)
 }
 
+// ToProto implements protocol.HasProto.
+func (a *MemoryAccount) ToProto() proto.Message {
+	var tests []string
+	if a.AuthenticatedLengthExperiment {
+		tests = append(tests, "AuthenticatedLength")
+	}
+	if a.NoTerminationSignal {
+		tests = append(tests, "NoTerminationSignal")
+	}
+	return &Account{
+		Id:               a.ID.String(),
+		AlterId:          uint32(len(a.AlterIDs)),
+		SecuritySettings: &protocol.SecurityConfig{Type: a.Security},
+		TestsEnabled:     strings.Join(tests, "|"),
+	}
+}
+
 // AsAccount implements protocol.Account.
 func (a *Account) AsAccount() (protocol.Account, error) {
 	id, err := uuid.ParseString(a.Id)
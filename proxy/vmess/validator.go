@@ -159,6 +159,27 @@ func (v *TimedUserValidator) Add(u *protocol.MemoryUser) error {
 	return nil
 }
 
+// GetUsers returns all users.
+func (v *TimedUserValidator) GetUsers() []*protocol.MemoryUser {
+	v.RLock()
+	defer v.RUnlock()
+
+	users := make([]*protocol.MemoryUser, 0, len(v.users))
+	for _, u := range v.users {
+		user := u.user
+		users = append(users, &user)
+	}
+	return users
+}
+
+// GetCount returns the number of users.
+func (v *TimedUserValidator) GetCount() int64 {
+	v.RLock()
+	defer v.RUnlock()
+
+	return int64(len(v.users))
+}
+
 func (v *TimedUserValidator) Get(userHash []byte) (*protocol.MemoryUser, protocol.Timestamp, bool, error) {
 	v.RLock()
 	defer v.RUnlock()
@@ -87,6 +87,39 @@ func TestUserValidator(t *testing.T) {
 	}
 }
 
+func TestUserValidatorGetUsers(t *testing.T) {
+	v := NewTimedUserValidator(protocol.DefaultIDHash)
+	defer common.Close(v)
+
+	for _, email := range []string{"a", "b"} {
+		id := uuid.New()
+		common.Must(v.Add(&protocol.MemoryUser{
+			Email: email,
+			Account: toAccount(&Account{
+				Id: id.String(),
+			}),
+		}))
+	}
+
+	if c := v.GetCount(); c != 2 {
+		t.Error("unexpected user count: ", c)
+	}
+	for _, user := range v.GetUsers() {
+		if _, ok := user.Account.(*MemoryAccount); !ok {
+			t.Error("unexpected account type for user ", user.Email)
+		}
+		u := user.ToProtoUser()
+		if u.Email != user.Email || u.Account == nil {
+			t.Error("failed to convert user ", user.Email)
+		}
+	}
+
+	v.Remove("a")
+	if c := v.GetCount(); c != 1 {
+		t.Error("unexpected user count: ", c)
+	}
+}
+
 func BenchmarkUserValidator(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		hasher := protocol.DefaultIDHash